@@ -0,0 +1,144 @@
+package dbpu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/conneroisu/dbpu/internal/builders"
+)
+
+// ErrTokenExpired is matched by errors.Is when the API token of the Client is
+// expired. It also matches ErrUnauthorized.
+var ErrTokenExpired = fmt.Errorf("%w: api token expired", ErrUnauthorized)
+
+type (
+	// APIToken is a platform API token of the user.
+	APIToken struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	// NewAPIToken is a platform API token returned by CreateAPIToken.
+	NewAPIToken struct {
+		APIToken
+		// Token is the secret value of the token. It cannot be retrieved
+		// again.
+		Token string `json:"token"`
+	}
+)
+
+// CreateAPIToken creates a platform API token with the given name.
+func (c *Client) CreateAPIToken(
+	ctx context.Context,
+	name string,
+) (*NewAPIToken, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodPost,
+		fmt.Sprintf("%s/auth/api-tokens/%s", c.baseURL, name),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp NewAPIToken
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api token %s: %w", name, err)
+	}
+	return &resp, nil
+}
+
+// ListAPITokens lists the platform API tokens of the user.
+func (c *Client) ListAPITokens(ctx context.Context) ([]APIToken, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		fmt.Sprintf("%s/auth/api-tokens", c.baseURL),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Tokens []APIToken `json:"tokens"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api tokens: %w", err)
+	}
+	return resp.Tokens, nil
+}
+
+// RevokeAPIToken revokes the platform API token with the given name.
+func (c *Client) RevokeAPIToken(ctx context.Context, name string) error {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodDelete,
+		fmt.Sprintf("%s/auth/api-tokens/%s", c.baseURL, name),
+	)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Token string `json:"token"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api token %s: %w", name, err)
+	}
+	return nil
+}
+
+// ValidateAPIToken validates the platform API token of the Client and
+// returns the time it expires, zero if it never expires.
+func (c *Client) ValidateAPIToken(ctx context.Context) (time.Time, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		fmt.Sprintf("%s/auth/validate", c.baseURL),
+	)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var resp struct {
+		Exp int64 `json:"exp"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to validate api token: %w", err)
+	}
+	if resp.Exp <= 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(resp.Exp, 0), nil
+}
+
+// ValidateToken checks that the platform API token of the Client is valid, not
+// expired and can access the organization of the Client.
+//
+// It is meant to be called at startup to fail fast: the returned error
+// matches ErrUnauthorized if the token is rejected, and ErrTokenExpired if it
+// is expired.
+func (c *Client) ValidateToken(ctx context.Context) error {
+	exp, err := c.ValidateAPIToken(ctx)
+	if err != nil {
+		return err
+	}
+	if !exp.IsZero() && !time.Now().Before(exp) {
+		return fmt.Errorf("%w at %s", ErrTokenExpired, exp.Format(time.RFC3339))
+	}
+	_, err = c.GetOrganization(ctx)
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf(
+			"%w: organization %s is not accessible: %w",
+			ErrUnauthorized, c.orgName, err,
+		)
+	}
+	return err
+}
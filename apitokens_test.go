@@ -0,0 +1,83 @@
+package dbpu
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		validate  func(w http.ResponseWriter)
+		orgStatus int
+		want      error
+		notWant   error
+	}{
+		{
+			name: "valid",
+			validate: func(w http.ResponseWriter) {
+				_, _ = fmt.Fprintf(w, `{"exp":%d}`, time.Now().Add(time.Hour).Unix())
+			},
+			orgStatus: http.StatusOK,
+		},
+		{
+			name: "expired",
+			validate: func(w http.ResponseWriter) {
+				_, _ = fmt.Fprintf(w, `{"exp":%d}`, time.Now().Add(-time.Hour).Unix())
+			},
+			want: ErrTokenExpired,
+		},
+		{
+			name: "rejected",
+			validate: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"invalid token"}`))
+			},
+			want:    ErrUnauthorized,
+			notWant: ErrTokenExpired,
+		},
+		{
+			name: "organization",
+			validate: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte(`{"exp":-1}`))
+			},
+			orgStatus: http.StatusNotFound,
+			want:      ErrUnauthorized,
+			notWant:   ErrTokenExpired,
+		},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/auth/validate":
+				tt.validate(w)
+			case "/organizations/org":
+				w.WriteHeader(tt.orgStatus)
+				if tt.orgStatus != http.StatusOK {
+					_, _ = w.Write([]byte(`{"error":"organization not found"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"organization":{"slug":"org"}}`))
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		err := NewClient("token", "org", WithBaseURL(srv.URL)).
+			ValidateToken(context.Background())
+		srv.Close()
+		if tt.want == nil {
+			assert.NoError(t, err, tt.name)
+			continue
+		}
+		assert.ErrorIs(t, err, tt.want, tt.name)
+		if tt.notWant != nil {
+			assert.NotErrorIs(t, err, tt.notWant, tt.name)
+		}
+	}
+}
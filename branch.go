@@ -0,0 +1,129 @@
+package dbpu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrOutsideRetention is matched by errors.Is when a branch is requested at a
+// time outside the retention window of its source database.
+var ErrOutsideRetention = errors.New("dbpu: timestamp outside retention window")
+
+type (
+	branchOpt func(*branchConfig)
+
+	// branchConfig is a configuration for branching a database.
+	branchConfig struct {
+		retention time.Duration
+		tokenOpts []newDbTokenOpt
+		now       func() time.Time
+	}
+)
+
+// WithRetention sets the point-in-time retention window of the source
+// database, which depends on the plan of the organization. When set, Branch
+// rejects older timestamps before sending any request.
+func WithRetention(retention time.Duration) func(*branchConfig) {
+	return func(c *branchConfig) { c.retention = retention }
+}
+
+// WithBranchToken sets the options of the token minted for the branch.
+func WithBranchToken(opts ...newDbTokenOpt) func(*branchConfig) {
+	return func(c *branchConfig) { c.tokenOpts = opts }
+}
+
+// Branch creates a database with the given name from the source database,
+// in the same group and location, and mints a token for it.
+//
+// If at is nil, the branch copies the current state of the source. Otherwise
+// it restores the source as it was at the given time, which must fall within
+// the retention window of the source. Timestamps in the future fail with
+// ErrOutsideRetention. The retention window is not exposed by the turso API,
+// so older timestamps are only rejected client-side when WithRetention is
+// given; otherwise the API has the final say.
+//
+// The branch is placed in the primary region of the source, falling back to
+// the primary location of its group if the region is unknown.
+func (c *Client) Branch(
+	ctx context.Context,
+	source, newName string,
+	at *time.Time,
+	opts ...branchOpt,
+) (*Database, string, error) {
+	config := branchConfig{now: time.Now}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if at != nil {
+		now := config.now()
+		switch {
+		case at.After(now):
+			return nil, "", fmt.Errorf(
+				"%w: %s is in the future",
+				ErrOutsideRetention, at.Format(time.RFC3339),
+			)
+		case config.retention > 0 && at.Before(now.Add(-config.retention)):
+			return nil, "", fmt.Errorf(
+				"%w: %s is older than %s",
+				ErrOutsideRetention, at.Format(time.RFC3339), config.retention,
+			)
+		}
+	}
+	src, err := c.GetDatabase(ctx, source)
+	if err != nil {
+		return nil, "", err
+	}
+	location, err := c.branchLocation(ctx, src)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to branch %s: %w", source, err)
+	}
+	db, err := c.Create(ctx, Config{
+		Name:     newName,
+		Location: location,
+		Group:    src.Group,
+		Seed: &Seed{
+			Type:      SeedDatabase,
+			Name:      source,
+			Timestamp: at,
+		},
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to branch %s: %w", source, err)
+	}
+	token, err := c.CreateDatabaseToken(ctx, db.Name, config.tokenOpts...)
+	if err != nil {
+		return db, "", err
+	}
+	return db, token, nil
+}
+
+// branchLocation returns the location of a branch of the source database.
+//
+// It is the primary region of the source or, if it is missing or unknown to
+// the catalog of the Client, the primary location of its group. If neither
+// is known, the catalog is refreshed from the turso API.
+func (c *Client) branchLocation(
+	ctx context.Context,
+	src *Database,
+) (Location, error) {
+	location := Location(src.PrimaryRegion)
+	if (location == "" || !c.locations.has(location)) && src.Group != "" {
+		group, err := c.GetGroup(ctx, src.Group)
+		if err != nil {
+			return "", err
+		}
+		if primary := Location(group.Primary); primary != "" &&
+			(location == "" || c.locations.has(primary)) {
+			location = primary
+		}
+	}
+	if location != "" && !c.locations.has(location) {
+		_, err := c.ListLocations(ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+	return location, nil
+}
@@ -0,0 +1,96 @@
+package dbpu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranch_Location(t *testing.T) {
+	tests := []struct {
+		region string
+		want   Location
+	}{
+		{"lhr", "lhr"},
+		{"", "ams"},
+		{"unknown-region", "ams"},
+	}
+	for _, tt := range tests {
+		var created Config
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path := strings.TrimPrefix(r.URL.Path, "/organizations/org")
+			switch {
+			case path == "/databases/src":
+				_, _ = fmt.Fprintf(w, `{"database":{"Name":"src","group":"default","primaryRegion":%q}}`, tt.region)
+			case path == "/groups/default":
+				_, _ = w.Write([]byte(`{"group":{"name":"default","primary":"ams"}}`))
+			case path == "/databases" && r.Method == http.MethodPost:
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+				_, _ = w.Write([]byte(`{"database":{"Name":"branch"}}`))
+			case strings.HasSuffix(path, "/auth/tokens"):
+				_, _ = w.Write([]byte(`{"jwt":"token"}`))
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		c := NewClient("token", "org", WithBaseURL(srv.URL))
+		db, token, err := c.Branch(context.Background(), "src", "branch", nil)
+		srv.Close()
+		require.NoError(t, err, tt.region)
+		assert.Equal(t, "branch", db.Name, tt.region)
+		assert.Equal(t, "token", token, tt.region)
+		assert.Equal(t, tt.want, created.Location, tt.region)
+		assert.Equal(t, "default", created.Group, tt.region)
+		assert.Equal(t, "src", created.Seed.Name, tt.region)
+	}
+}
+
+func TestBranch_Retention(t *testing.T) {
+	var created Config
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		path := strings.TrimPrefix(r.URL.Path, "/organizations/org")
+		switch {
+		case path == "/databases/src":
+			_, _ = w.Write([]byte(`{"database":{"Name":"src","group":"default","primaryRegion":"ams"}}`))
+		case path == "/databases":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			_, _ = w.Write([]byte(`{"database":{"Name":"branch"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"jwt":"token"}`))
+		}
+	}))
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	future := time.Now().Add(time.Hour)
+	_, _, err := c.Branch(ctx, "src", "branch", &future)
+	assert.ErrorIs(t, err, ErrOutsideRetention)
+
+	old := time.Now().Add(-72 * time.Hour)
+	_, _, err = c.Branch(ctx, "src", "branch", &old, WithRetention(24*time.Hour))
+	assert.ErrorIs(t, err, ErrOutsideRetention)
+	assert.Zero(t, requests)
+
+	// Without a retention window, the API decides.
+	_, _, err = c.Branch(ctx, "src", "branch", &old)
+	require.NoError(t, err)
+	require.NotNil(t, created.Seed.Timestamp)
+	assert.True(t, old.Equal(*created.Seed.Timestamp))
+
+	recent := time.Now().Add(-time.Hour)
+	_, _, err = c.Branch(ctx, "src", "branch", &recent, WithRetention(24*time.Hour))
+	require.NoError(t, err)
+}
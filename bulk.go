@@ -0,0 +1,354 @@
+package dbpu
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BulkStatus is the outcome of the creation of a database in a bulk.
+type BulkStatus string
+
+const (
+	// BulkCreated is the status of a database created by the bulk.
+	BulkCreated BulkStatus = "created"
+	// BulkAlreadyExists is the status of a database that already existed.
+	BulkAlreadyExists BulkStatus = "already_exists"
+	// BulkInvalid is the status of a config that failed validation.
+	BulkInvalid BulkStatus = "invalid"
+	// BulkFailed is the status of a database the turso API failed to create.
+	BulkFailed BulkStatus = "failed"
+	// BulkSkipped is the status of a database completed by a previous run
+	// according to the checkpoint.
+	BulkSkipped BulkStatus = "skipped"
+)
+
+type (
+	bulkOpt func(*bulkConfig)
+
+	// bulkConfig is a configuration for the creation of databases in bulk.
+	bulkConfig struct {
+		workers    int
+		progress   func(BulkProgress)
+		checkpoint Checkpoint
+	}
+
+	// BulkResult is the result of the creation of a database in a bulk.
+	BulkResult struct {
+		// Index is the position of the config in the input.
+		Index int
+		// Config is the config of the database.
+		Config Config
+		// Status is the outcome of the creation.
+		Status BulkStatus
+		// Database is the created database, nil unless Status is
+		// BulkCreated.
+		Database *Database
+		// Err is the error of the creation, nil if Status is BulkCreated or
+		// BulkSkipped.
+		Err error
+	}
+
+	// BulkReport is the report of the creation of databases in bulk.
+	//
+	// Results are grouped by status in completion order.
+	BulkReport struct {
+		Created       []BulkResult
+		AlreadyExists []BulkResult
+		Invalid       []BulkResult
+		Failed        []BulkResult
+		Skipped       []BulkResult
+	}
+
+	// BulkProgress is a snapshot of the progress of a bulk.
+	BulkProgress struct {
+		// Done is the number of processed configs.
+		Done int
+		// Total is the number of configs, zero if unknown.
+		Total         int
+		Created       int
+		AlreadyExists int
+		Invalid       int
+		Failed        int
+		Skipped       int
+		// Elapsed is the time since the start of the bulk.
+		Elapsed time.Duration
+	}
+
+	// Checkpoint records the databases completed by a bulk so that an
+	// interrupted bulk can be resumed.
+	//
+	// Implementations must be safe for concurrent use.
+	Checkpoint interface {
+		// Completed reports whether the database with the given name was
+		// completed by a previous run.
+		Completed(name string) bool
+		// Complete records the database with the given name as completed.
+		Complete(name string) error
+	}
+
+	// FileCheckpoint is a Checkpoint persisting the names of the completed
+	// databases to a file, one per line.
+	FileCheckpoint struct {
+		mu   sync.Mutex
+		file *os.File
+		done map[string]struct{}
+	}
+
+	indexedConfig struct {
+		index  int
+		config Config
+	}
+)
+
+// WithWorkers sets the number of databases created concurrently by a bulk,
+// defaulting to 8.
+func WithWorkers(workers int) func(*bulkConfig) {
+	return func(c *bulkConfig) { c.workers = workers }
+}
+
+// WithProgress sets a callback called after every processed config of a bulk.
+//
+// Calls are serialized.
+func WithProgress(progress func(BulkProgress)) func(*bulkConfig) {
+	return func(c *bulkConfig) { c.progress = progress }
+}
+
+// WithCheckpoint sets the checkpoint used to skip databases completed by a
+// previous run and to record the databases completed by the bulk.
+func WithCheckpoint(checkpoint Checkpoint) func(*bulkConfig) {
+	return func(c *bulkConfig) { c.checkpoint = checkpoint }
+}
+
+// BulkCreate creates the databases of the given configs concurrently.
+//
+// The returned report holds a result per processed config. If the context is
+// done before every config is processed, the partial report is returned
+// along with the error of the context.
+func (c *Client) BulkCreate(
+	ctx context.Context,
+	configs []Config,
+	opts ...bulkOpt,
+) (*BulkReport, error) {
+	in := make(chan Config)
+	go func() {
+		defer close(in)
+		for _, config := range configs {
+			select {
+			case in <- config:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c.bulkCreate(ctx, in, len(configs), opts)
+}
+
+// BulkCreateStream creates the databases of the configs received from the
+// given channel concurrently until it is closed.
+//
+// See BulkCreate.
+func (c *Client) BulkCreateStream(
+	ctx context.Context,
+	configs <-chan Config,
+	opts ...bulkOpt,
+) (*BulkReport, error) {
+	return c.bulkCreate(ctx, configs, 0, opts)
+}
+
+func (c *Client) bulkCreate(
+	ctx context.Context,
+	configs <-chan Config,
+	total int,
+	opts []bulkOpt,
+) (*BulkReport, error) {
+	config := bulkConfig{workers: 8}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.workers < 1 {
+		config.workers = 1
+	}
+	var (
+		report  BulkReport
+		errs    []error
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		start   = time.Now()
+		indexed = make(chan indexedConfig)
+	)
+	progress := BulkProgress{Total: total}
+	go func() {
+		defer close(indexed)
+		index := 0
+		for {
+			select {
+			case cfg, ok := <-configs:
+				if !ok {
+					return
+				}
+				// Workers drain indexed until it is closed, so a received
+				// config is always reported.
+				indexed <- indexedConfig{index: index, config: cfg}
+				index++
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	for i := 0; i < config.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range indexed {
+				// An item received after the context is done is still
+				// reported so that every processed config is accounted for.
+				result := BulkResult{
+					Index:  item.index,
+					Config: item.config,
+					Status: BulkFailed,
+					Err:    ctx.Err(),
+				}
+				if result.Err == nil {
+					result = c.bulkCreateOne(ctx, config.checkpoint, item)
+				}
+				var checkpointErr error
+				if config.checkpoint != nil &&
+					(result.Status == BulkCreated ||
+						result.Status == BulkAlreadyExists) {
+					checkpointErr = config.checkpoint.Complete(result.Config.Name)
+				}
+				mu.Lock()
+				if checkpointErr != nil {
+					errs = append(errs, fmt.Errorf(
+						"failed to checkpoint %s: %w",
+						result.Config.Name, checkpointErr,
+					))
+				}
+				report.add(result, &progress)
+				if config.progress != nil {
+					progress.Elapsed = time.Since(start)
+					config.progress(progress)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		errs = append(errs, ctx.Err())
+	}
+	return &report, errors.Join(errs...)
+}
+
+func (c *Client) bulkCreateOne(
+	ctx context.Context,
+	checkpoint Checkpoint,
+	item indexedConfig,
+) BulkResult {
+	result := BulkResult{Index: item.index, Config: item.config}
+	if checkpoint != nil && checkpoint.Completed(item.config.Name) {
+		result.Status = BulkSkipped
+		return result
+	}
+	err := c.validate(item.config)
+	if err != nil {
+		result.Status = BulkInvalid
+		result.Err = err
+		return result
+	}
+	result.Database, result.Err = c.Create(ctx, item.config)
+	switch {
+	case result.Err == nil:
+		result.Status = BulkCreated
+	case errors.Is(result.Err, ErrAlreadyExists):
+		result.Status = BulkAlreadyExists
+	default:
+		result.Status = BulkFailed
+	}
+	return result
+}
+
+// add adds the result to the report and counts it in the progress.
+func (r *BulkReport) add(result BulkResult, progress *BulkProgress) {
+	progress.Done++
+	switch result.Status {
+	case BulkCreated:
+		r.Created = append(r.Created, result)
+		progress.Created++
+	case BulkAlreadyExists:
+		r.AlreadyExists = append(r.AlreadyExists, result)
+		progress.AlreadyExists++
+	case BulkInvalid:
+		r.Invalid = append(r.Invalid, result)
+		progress.Invalid++
+	case BulkSkipped:
+		r.Skipped = append(r.Skipped, result)
+		progress.Skipped++
+	default:
+		r.Failed = append(r.Failed, result)
+		progress.Failed++
+	}
+}
+
+// Rate returns the number of configs processed per second.
+func (p BulkProgress) Rate() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Done) / p.Elapsed.Seconds()
+}
+
+// OpenFileCheckpoint opens the checkpoint file at the given path, creating it
+// if it does not exist, and loads the databases it records as completed.
+func OpenFileCheckpoint(path string) (*FileCheckpoint, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	done := map[string]struct{}{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if name := strings.TrimSpace(scanner.Text()); name != "" {
+			done[name] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &FileCheckpoint{file: file, done: done}, nil
+}
+
+// Completed reports whether the database with the given name is recorded as
+// completed.
+func (f *FileCheckpoint) Completed(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.done[name]
+	return ok
+}
+
+// Complete records the database with the given name as completed.
+func (f *FileCheckpoint) Complete(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.done[name]; ok {
+		return nil
+	}
+	if _, err := fmt.Fprintln(f.file, name); err != nil {
+		return err
+	}
+	f.done[name] = struct{}{}
+	return nil
+}
+
+// Close closes the checkpoint file.
+func (f *FileCheckpoint) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,168 @@
+package dbpu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkServer answers database creations by name: names starting with
+// "exists" conflict, names starting with "broken" fail and the others are
+// created after the given delay.
+func bulkServer(t *testing.T, delay time.Duration, inFlight, maxInFlight *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if inFlight != nil {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				max := maxInFlight.Load()
+				if n <= max || maxInFlight.CompareAndSwap(max, n) {
+					break
+				}
+			}
+		}
+		time.Sleep(delay)
+		var config Config
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&config))
+		switch {
+		case len(config.Name) >= 6 && config.Name[:6] == "exists":
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":"database already exists"}`))
+		case len(config.Name) >= 6 && config.Name[:6] == "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"internal error"}`))
+		default:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"database":{"Name":%q}}`, config.Name)
+		}
+	}))
+}
+
+func bulkConfigs(names ...string) []Config {
+	configs := make([]Config, 0, len(names))
+	for _, name := range names {
+		configs = append(configs, Config{Name: name, Location: "ams", Group: "default"})
+	}
+	return configs
+}
+
+func TestBulkCreate_Statuses(t *testing.T) {
+	srv := bulkServer(t, 0, nil, nil)
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+
+	var last BulkProgress
+	report, err := c.BulkCreate(context.Background(),
+		bulkConfigs("db-a", "db-b", "exists-a", "Bad_Name!", "broken-a"),
+		WithProgress(func(p BulkProgress) { last = p }),
+	)
+	require.NoError(t, err)
+	assert.Len(t, report.Created, 2)
+	assert.Len(t, report.AlreadyExists, 1)
+	assert.Len(t, report.Invalid, 1)
+	assert.Len(t, report.Failed, 1)
+	assert.Equal(t, 3, report.Invalid[0].Index)
+	assert.Equal(t, 5, last.Done)
+	assert.Equal(t, 5, last.Total)
+	assert.Equal(t, 2, last.Created)
+}
+
+func TestBulkCreate_Workers(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	srv := bulkServer(t, 10*time.Millisecond, &inFlight, &maxInFlight)
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+
+	names := make([]string, 12)
+	for i := range names {
+		names[i] = fmt.Sprintf("db-%d", i)
+	}
+	report, err := c.BulkCreate(context.Background(), bulkConfigs(names...),
+		WithWorkers(3),
+	)
+	require.NoError(t, err)
+	assert.Len(t, report.Created, 12)
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+}
+
+func TestBulkCreate_Cancel(t *testing.T) {
+	srv := bulkServer(t, 5*time.Millisecond, nil, nil)
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	configs := make(chan Config)
+	var sent int
+	producerDone := make(chan struct{})
+	go func() {
+		defer close(producerDone)
+		defer close(configs)
+		for i := 0; i < 50; i++ {
+			select {
+			case configs <- bulkConfigs(fmt.Sprintf("db-%d", i))[0]:
+				sent++
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	report, err := c.BulkCreateStream(ctx, configs,
+		WithWorkers(2),
+		WithProgress(func(p BulkProgress) {
+			if p.Done == 4 {
+				cancel()
+			}
+		}),
+	)
+	assert.ErrorIs(t, err, context.Canceled)
+	<-producerDone
+	processed := len(report.Created) + len(report.AlreadyExists) +
+		len(report.Invalid) + len(report.Failed) + len(report.Skipped)
+	assert.Equal(t, sent, processed)
+	assert.Less(t, processed, 50)
+	for _, result := range report.Failed {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
+
+func TestBulkCreate_Checkpoint(t *testing.T) {
+	srv := bulkServer(t, 0, nil, nil)
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+	path := filepath.Join(t.TempDir(), "checkpoint")
+
+	checkpoint, err := OpenFileCheckpoint(path)
+	require.NoError(t, err)
+	report, err := c.BulkCreate(context.Background(),
+		bulkConfigs("db-a", "exists-a", "broken-a"),
+		WithCheckpoint(checkpoint),
+	)
+	require.NoError(t, err)
+	assert.Len(t, report.Failed, 1)
+	require.NoError(t, checkpoint.Close())
+
+	checkpoint, err = OpenFileCheckpoint(path)
+	require.NoError(t, err)
+	defer checkpoint.Close()
+	assert.True(t, checkpoint.Completed("db-a"))
+	assert.True(t, checkpoint.Completed("exists-a"))
+	assert.False(t, checkpoint.Completed("broken-a"))
+	report, err = c.BulkCreate(context.Background(),
+		bulkConfigs("db-a", "exists-a", "broken-a"),
+		WithCheckpoint(checkpoint),
+	)
+	require.NoError(t, err)
+	assert.Len(t, report.Skipped, 2)
+	assert.Len(t, report.Failed, 1)
+	assert.Equal(t, "broken-a", report.Failed[0].Config.Name)
+}
@@ -2,6 +2,7 @@ package dbpu
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -27,7 +28,10 @@ type (
 		orgName   string // Name of organization.
 		header    builders.Header
 		validator *validator.Validate
-		apiToken  string // Token for API.
+		locations *locationCatalog // Locations accepted by validate.
+		retry     RetryPolicy      // Policy for retrying failed requests.
+		limiter   *RateLimiter     // Limiter shared by clients, if any.
+		apiToken  string           // Token for API.
 	}
 	// option is a functional option for configuring a Client.
 	option func(*Client)
@@ -54,10 +58,14 @@ func NewClient(apiToken, orgName string, opts ...option) *Client {
 		regionURL: DefaultRegionURL,
 		apiToken:  apiToken,
 		orgName:   orgName,
-		validator: validator.New(),
+		locations: newLocationCatalog(),
 	}
+	client.validator = newValidator(client.locations)
 	client.header.SetCommonHeaders = func(req *http.Request) {
-		req.Header.Set("Content-Type", "application/json")
+		// Keep the Content-Type of multipart requests.
+		if req.Header.Get("Content-Type") == "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
 		req.Header.Set("Authorization", fmt.Sprintf(
 			"Bearer %s",
 			client.apiToken,
@@ -69,6 +77,23 @@ func NewClient(apiToken, orgName string, opts ...option) *Client {
 	return client
 }
 
+// Org returns a view of the Client scoped to the organization with the given
+// name.
+//
+// The view shares the HTTP client, headers, rate limiter, retry policy and
+// known locations of the Client, so every method of the Client can be used on
+// it to manage the resources of another organization.
+func (c *Client) Org(name string) *Client {
+	org := *c
+	org.orgName = name
+	return &org
+}
+
+// OrgName returns the name of the organization the Client is scoped to.
+func (c *Client) OrgName() string {
+	return c.orgName
+}
+
 func (c *Client) sendRequest(req *http.Request, v any) error {
 	req.Header.Set("Accept", "application/json")
 	// Check whether Content-Type is already set, Upload Files API requires
@@ -77,7 +102,7 @@ func (c *Client) sendRequest(req *http.Request, v any) error {
 	if contentType == "" {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	res, err := c.client.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -85,18 +110,27 @@ func (c *Client) sendRequest(req *http.Request, v any) error {
 	if isFailureStatusCode(res) {
 		return c.handleErrorResp(res)
 	}
+	if v == nil {
+		// The response has no body worth decoding.
+		return nil
+	}
 	return decode(res.Body, v)
 }
 
 func (c *Client) handleErrorResp(resp *http.Response) error {
 	var errRes tursoerr.ErrorResponse
 	err := json.NewDecoder(resp.Body).Decode(&errRes)
+	if errors.Is(err, io.EOF) {
+		// An empty body is described by the status code alone.
+		err = nil
+	}
 	if err != nil || errRes.Error == nil {
 		reqErr := &tursoerr.ErrRequest{
 			HTTPStatusCode: resp.StatusCode,
 			Err:            err,
 		}
 		if errRes.Error != nil {
+			errRes.Error.HTTPStatusCode = resp.StatusCode
 			reqErr.Err = errRes.Error
 		}
 		return reqErr
@@ -105,16 +139,16 @@ func (c *Client) handleErrorResp(resp *http.Response) error {
 	return errRes.Error
 }
 
+// validate validates the struct, returning a *ValidationError listing the
+// failing fields.
 func (c *Client) validate(v any) error {
 	err := c.validator.Struct(v)
 	if err != nil {
-		errs := []error{}
-		for _, err := range err.(validator.ValidationErrors) {
-			errs = append(errs, err)
+		var errs validator.ValidationErrors
+		if errors.As(err, &errs) {
+			return newValidationError(errs)
 		}
-
-		// TODO: better error handling/reporting
-		return fmt.Errorf("validation failed: %v", errs)
+		return err
 	}
 	return nil
 }
@@ -0,0 +1,41 @@
+package dbpu
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Org(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"databases":[]}`))
+	}))
+	defer srv.Close()
+
+	limiter := NewRateLimiter(100, 100, 10)
+	c := NewClient("token", "main",
+		WithBaseURL(srv.URL),
+		WithRateLimiter(limiter),
+	)
+	enterprise := c.Org("enterprise")
+	assert.Equal(t, "main", c.OrgName())
+	assert.Equal(t, "enterprise", enterprise.OrgName())
+	assert.Same(t, c.limiter, enterprise.limiter)
+	assert.Same(t, c.client, enterprise.client)
+
+	_, err := c.ListDatabases(context.Background())
+	require.NoError(t, err)
+	_, err = enterprise.ListDatabases(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"/organizations/main/databases",
+		"/organizations/enterprise/databases",
+	}, paths)
+}
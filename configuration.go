@@ -0,0 +1,91 @@
+package dbpu
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/conneroisu/dbpu/internal/builders"
+)
+
+// DatabaseConfig is the configuration of a database.
+//
+// Nil fields are not sent by UpdateDatabaseConfig, so a partial update leaves
+// the other settings unchanged. Use Ptr to set a field.
+type DatabaseConfig struct {
+	// SizeLimit is the maximum size of the database (e.g., 500mb or 1gb).
+	SizeLimit *string `json:"size_limit,omitempty"`
+	// AllowAttach allows the database to be attached by other databases.
+	AllowAttach *bool `json:"allow_attach,omitempty"`
+	// BlockReads blocks the reads of the database.
+	BlockReads *bool `json:"block_reads,omitempty"`
+	// BlockWrites blocks the writes of the database.
+	BlockWrites *bool `json:"block_writes,omitempty"`
+	// DeleteProtection prevents the database from being deleted.
+	DeleteProtection *bool `json:"delete_protection,omitempty"`
+}
+
+// Ptr returns a pointer to the given value.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// GetDatabaseConfig returns the configuration of the database with the given
+// name.
+func (c *Client) GetDatabaseConfig(
+	ctx context.Context,
+	dbName string,
+) (*DatabaseConfig, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		fmt.Sprintf(
+			"%s/organizations/%s/databases/%s/configuration",
+			c.baseURL, c.orgName, dbName,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp DatabaseConfig
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get configuration of database %s: %w",
+			dbName, err,
+		)
+	}
+	return &resp, nil
+}
+
+// UpdateDatabaseConfig updates the set fields of the configuration of the
+// database with the given name and returns the resulting configuration.
+func (c *Client) UpdateDatabaseConfig(
+	ctx context.Context,
+	dbName string,
+	config DatabaseConfig,
+) (*DatabaseConfig, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodPatch,
+		fmt.Sprintf(
+			"%s/organizations/%s/databases/%s/configuration",
+			c.baseURL, c.orgName, dbName,
+		),
+		builders.WithBody(config),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp DatabaseConfig
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to update configuration of database %s: %w",
+			dbName, err,
+		)
+	}
+	return &resp, nil
+}
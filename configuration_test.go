@@ -0,0 +1,42 @@
+package dbpu
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseConfig(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/organizations/org/databases/db/configuration", r.URL.Path)
+		if r.Method == http.MethodPatch {
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			body = string(raw)
+		}
+		_, _ = w.Write([]byte(`{"size_limit":"1gb","allow_attach":true,"block_reads":false,"block_writes":false,"delete_protection":true}`))
+	}))
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	config, err := c.GetDatabaseConfig(ctx, "db")
+	require.NoError(t, err)
+	assert.Equal(t, &DatabaseConfig{
+		SizeLimit:        Ptr("1gb"),
+		AllowAttach:      Ptr(true),
+		BlockReads:       Ptr(false),
+		BlockWrites:      Ptr(false),
+		DeleteProtection: Ptr(true),
+	}, config)
+
+	_, err = c.UpdateDatabaseConfig(ctx, "db", DatabaseConfig{BlockReads: Ptr(false)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"block_reads":false}`, body)
+}
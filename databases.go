@@ -24,29 +24,78 @@ type Database struct {
 
 // Config is a struct configures the creation of a database.
 type Config struct {
-	Name       string `json:"name" validate:"required"`
-	Location   string `json:"location" validate:"required"`
-	Group      string `json:"group" validate:"required"`
-	Image      string `json:"image,omitempty"`
-	Extensions string `json:"extensions,omitempty"`
-	Seed       *Seed  `json:"seed,omitempty"`
-	Schema     string `json:"schema,omitempty"`
-	IsSchema   bool   `json:"is_schema,omitempty"`
+	Name       string   `json:"name" validate:"required,turso_name"`
+	Location   Location `json:"location" validate:"required,turso_location"`
+	Group      string   `json:"group" validate:"required"`
+	Image      string   `json:"image,omitempty"`
+	Extensions string   `json:"extensions,omitempty"`
+	Seed       *Seed    `json:"seed,omitempty"`
+	Schema     string   `json:"schema,omitempty"`
+	IsSchema   bool     `json:"is_schema,omitempty"`
 }
 
+// Seed types supported by the turso API.
+const (
+	// SeedDatabase seeds a database from another database of the
+	// organization, optionally at a point in time.
+	SeedDatabase = "database"
+	// SeedDump seeds a database from the SQL dump at a URL.
+	SeedDump = "dump"
+	// SeedDatabaseUpload creates an empty database awaiting the upload of a
+	// SQLite file.
+	SeedDatabaseUpload = "database_upload"
+)
+
 // Seed is a seed for a database.
 type Seed struct {
-	Type      string     `json:"type"`
-	Name      string     `json:"value,omitempty"`
-	URL       string     `json:"url,omitempty"`
+	Type      string     `json:"type" validate:"required,oneof=database dump database_upload"`
+	Name      string     `json:"value,omitempty" validate:"required_if=Type database"`
+	URL       string     `json:"url,omitempty" validate:"required_if=Type dump,omitempty,url"`
 	Timestamp *time.Time `json:"timestamp,omitempty"`
 }
 
+type (
+	createOpt func(*createConfig)
+
+	// createConfig is a configuration for the creation of a database that is
+	// not sent to the turso API.
+	createConfig struct {
+		strategy NameStrategy
+		tenantID string
+	}
+)
+
+// WithNameStrategy fills the name of the created database by applying the
+// given strategy to the tenant ID.
+func WithNameStrategy(strategy NameStrategy, tenantID string) func(*createConfig) {
+	return func(c *createConfig) {
+		c.strategy = strategy
+		c.tenantID = tenantID
+	}
+}
+
 // Create creates a database with the given name and group.
 //
 // Options can be provided to configure the database such as location, image,
 // extensions, seed, schema, and isSchema.
-func (c *Client) Create(ctx context.Context, config Config) (*Database, error) {
+//
+// If a name strategy is given, it overrides the name of the config.
+func (c *Client) Create(
+	ctx context.Context,
+	config Config,
+	opts ...createOpt,
+) (*Database, error) {
+	create := createConfig{}
+	for _, opt := range opts {
+		opt(&create)
+	}
+	if create.strategy != nil {
+		name, err := create.strategy.Name(create.tenantID)
+		if err != nil {
+			return nil, err
+		}
+		config.Name = name
+	}
 	err := c.validate(config)
 	if err != nil {
 		return nil, err
@@ -64,35 +113,189 @@ func (c *Client) Create(ctx context.Context, config Config) (*Database, error) {
 	var resp struct {
 		Database Database `json:"database"`
 	}
-	err = c.sendRequest(req, resp)
+	err = c.sendRequest(req, &resp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create database: %v", err)
+		return nil, fmt.Errorf("failed to create database: %w", err)
 	}
 	return &resp.Database, nil
 }
 
+type (
+	listDbOpt func(*ListConfig)
+
+	// ListConfig is a configuration for listing the databases of an
+	// organization.
+	ListConfig struct {
+		// Group filters the databases by the group they belong to.
+		Group string `url:"group,omitempty"`
+		// Schema filters the databases by the schema database they use.
+		Schema string `url:"schema,omitempty"`
+	}
+)
+
+// WithGroupFilter filters the listed databases by the given group.
+func WithGroupFilter(group string) func(*ListConfig) {
+	return func(c *ListConfig) { c.Group = group }
+}
+
+// WithSchemaFilter filters the listed databases by the given schema database.
+func WithSchemaFilter(schema string) func(*ListConfig) {
+	return func(c *ListConfig) { c.Schema = schema }
+}
+
+// ListDatabases lists the databases of the organization.
+//
+// Options can be provided to filter the databases by group or schema.
+func (c *Client) ListDatabases(
+	ctx context.Context,
+	opts ...listDbOpt,
+) ([]Database, error) {
+	config := ListConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	uri, err := url.Parse(fmt.Sprintf(
+		"%s/organizations/%s/databases",
+		c.baseURL, c.orgName,
+	))
+	if err != nil {
+		return nil, err
+	}
+	vals, err := builders.Values(config)
+	if err != nil {
+		return nil, err
+	}
+	uri.RawQuery = vals.Encode()
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		uri.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Databases []Database `json:"databases"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list databases: %w", err)
+	}
+	return resp.Databases, nil
+}
+
+// GetDatabase returns the database with the given name.
+//
+// If the database does not exist, the returned error matches ErrNotFound.
+func (c *Client) GetDatabase(
+	ctx context.Context,
+	dbName string,
+) (*Database, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		fmt.Sprintf(
+			"%s/organizations/%s/databases/%s",
+			c.baseURL, c.orgName, dbName,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Database Database `json:"database"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database %s: %w", dbName, err)
+	}
+	return &resp.Database, nil
+}
+
+// DeleteDatabase deletes the database with the given name.
+//
+// If the database does not exist, the returned error matches ErrNotFound.
+func (c *Client) DeleteDatabase(ctx context.Context, dbName string) error {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodDelete,
+		fmt.Sprintf(
+			"%s/organizations/%s/databases/%s",
+			c.baseURL, c.orgName, dbName,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Database string `json:"database"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return fmt.Errorf("failed to delete database %s: %w", dbName, err)
+	}
+	return nil
+}
+
 type (
 	newDbTokenOpt func(*TokenConfig)
 
-	// TokenConfig is a configuration for creating a database token.
+	// TokenConfig is a configuration for creating a database or group token.
 	TokenConfig struct {
 		// Expiration time for the token (e.g., 2w1d30m).
 		expiration string `url:"expiration"`
 		// Authorization level for the token (full-access or read-only).
 		authorization string `url:"authorization"`
+		// Fine-grained permissions of the token, sent as the request body.
+		permissions *Permissions
+		// Error of an option, reported before the request is sent.
+		err error
 	}
 )
 
+// URLQuery sets the expiration and authorization of the token config as query
+// parameters of the given URL.
+func (t TokenConfig) URLQuery(u *url.URL) {
+	q := u.Query()
+	if t.expiration != "" {
+		q.Set("expiration", t.expiration)
+	}
+	if t.authorization != "" {
+		q.Set("authorization", t.authorization)
+	}
+	u.RawQuery = q.Encode()
+}
+
 // WithExpiration sets the expiration time for the token (e.g., 2w1d30m).
+//
+// The expiration is checked with ParseTokenExpiry before the request is sent.
 func WithExpiration(expiration string) func(*TokenConfig) {
 	return func(c *TokenConfig) { c.expiration = expiration }
 }
 
+// WithTokenExpiry sets the expiration of the token.
+//
+// Unlike WithExpiration, the expiry is validated before it is formatted.
+func WithTokenExpiry(expiry TokenExpiry) func(*TokenConfig) {
+	return func(c *TokenConfig) {
+		c.err = expiry.Validate()
+		c.expiration = expiry.String()
+	}
+}
+
 // WithAuthorization sets the authorization level for the token (full-access or read-only).
 func WithAuthorization(authorization string) func(*TokenConfig) {
 	return func(c *TokenConfig) { c.authorization = authorization }
 }
 
+// WithPermissions sets the fine-grained permissions of the token.
+func WithPermissions(permissions Permissions) func(*TokenConfig) {
+	return func(c *TokenConfig) { c.permissions = &permissions }
+}
+
 // CreateDatabaseToken creates a token for a database owned by an organization
 // with an optional given expiration and authorization.
 func (c *Client) CreateDatabaseToken(
@@ -100,36 +303,21 @@ func (c *Client) CreateDatabaseToken(
 	dbName string,
 	opts ...newDbTokenOpt,
 ) (string, error) {
-	config := TokenConfig{}
-	for _, opt := range opts {
-		opt(&config)
-	}
-	uri, err := url.Parse(fmt.Sprintf(
-		"%s/organizations/%s/databases/%s/auth/tokens",
-		c.baseURL, c.orgName, dbName,
-	))
-	if err != nil {
-		return "", err
-	}
-	vals, err := builders.Values(config)
-	if err != nil {
-		return "", err
-	}
-	uri.RawQuery = vals.Encode()
-	var resp struct {
-		Token string `json:"jwt"`
-	}
-	req, err := builders.NewRequest(
+	token, err := c.createToken(
 		ctx,
-		c.header,
-		http.MethodPost,
-		uri.String(),
+		fmt.Sprintf(
+			"%s/organizations/%s/databases/%s/auth/tokens",
+			c.baseURL, c.orgName, dbName,
+		),
+		opts,
 	)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf(
+			"failed to create token for database %s: %w",
+			dbName, err,
+		)
 	}
-	err = c.sendRequest(req, &resp)
-	return resp.Token, err
+	return token, nil
 }
 
 // ServerClient is a struct that contains the server and client locations.
@@ -151,5 +339,8 @@ func (c *Client) ClosestLocation(ctx context.Context) (*ServerClient, error) {
 	}
 	var resp ServerClient
 	err = c.sendRequest(req, &resp)
-	return &resp, err
+	if err != nil {
+		return nil, fmt.Errorf("failed to get closest location: %w", err)
+	}
+	return &resp, nil
 }
@@ -0,0 +1,51 @@
+package dbpu
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListDatabases_Filters(t *testing.T) {
+	srv, requests, _ := recordingServer(t, `{"databases":[{"Name":"a","group":"tenants"}]}`)
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+
+	dbs, err := c.ListDatabases(context.Background(),
+		WithGroupFilter("tenants"),
+		WithSchemaFilter("schema"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []Database{{Name: "a", Group: "tenants"}}, dbs)
+	assert.Equal(t, []string{
+		"GET /organizations/org/databases?group=tenants&schema=schema",
+	}, *requests)
+}
+
+func TestGetDatabase_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"database db not found"}`))
+	}))
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+
+	_, err := c.GetDatabase(context.Background(), "db")
+	assert.ErrorIs(t, err, ErrNotFound)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "database db not found", apiErr.Message)
+}
+
+func TestDeleteDatabase(t *testing.T) {
+	srv, requests, _ := recordingServer(t, `{"database":"db"}`)
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+
+	require.NoError(t, c.DeleteDatabase(context.Background(), "db"))
+	assert.Equal(t, []string{"DELETE /organizations/org/databases/db"}, *requests)
+}
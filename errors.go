@@ -0,0 +1,32 @@
+package dbpu
+
+import "github.com/conneroisu/dbpu/internal/tursoerr"
+
+type (
+	// APIError provides error information returned by the Turso API.
+	//
+	// Use errors.As to inspect the status code and message of a failed
+	// request.
+	APIError = tursoerr.APIError
+	// RequestError is returned when the Turso API responds with a failure
+	// status code but no parsable error body.
+	RequestError = tursoerr.ErrRequest
+)
+
+var (
+	// ErrNotFound is matched by errors.Is when the requested resource, such
+	// as a database, does not exist.
+	ErrNotFound = tursoerr.ErrNotFound
+	// ErrAlreadyExists is matched by errors.Is when the resource to create,
+	// such as a database, already exists.
+	ErrAlreadyExists = tursoerr.ErrAlreadyExists
+	// ErrUnauthorized is matched by errors.Is when the API token is invalid,
+	// expired or lacks the rights for the request.
+	ErrUnauthorized = tursoerr.ErrUnauthorized
+	// ErrRateLimited is matched by errors.Is when the request exceeded the
+	// rate limits of the turso API.
+	ErrRateLimited = tursoerr.ErrRateLimited
+	// ErrQuotaExceeded is matched by errors.Is when the request exceeded a
+	// quota of the organization, such as its number of databases.
+	ErrQuotaExceeded = tursoerr.ErrQuotaExceeded
+)
@@ -0,0 +1,221 @@
+package dbpu
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/conneroisu/dbpu/internal/builders"
+)
+
+// Group is a group of databases sharing the same locations.
+type Group struct {
+	ID        string   `json:"uuid"`
+	Name      string   `json:"name"`
+	Version   string   `json:"version"`
+	Primary   string   `json:"primary"`
+	Locations []string `json:"locations"`
+	Archived  bool     `json:"archived"`
+}
+
+// GroupConfig is a struct configures the creation of a group.
+type GroupConfig struct {
+	Name       string   `json:"name" validate:"required,turso_name"`
+	Location   Location `json:"location" validate:"required,turso_location"`
+	Extensions string   `json:"extensions,omitempty"`
+}
+
+// groupLocation is a location added to or removed from a group.
+type groupLocation struct {
+	Location Location `json:"location" validate:"required,turso_location"`
+}
+
+// CreateGroup creates a group with the given name in the given primary
+// location.
+func (c *Client) CreateGroup(
+	ctx context.Context,
+	config GroupConfig,
+) (*Group, error) {
+	err := c.validate(config)
+	if err != nil {
+		return nil, err
+	}
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodPost,
+		fmt.Sprintf("%s/organizations/%s/groups", c.baseURL, c.orgName),
+		builders.WithBody(config),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Group Group `json:"group"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+	return &resp.Group, nil
+}
+
+// ListGroups lists the groups of the organization.
+func (c *Client) ListGroups(ctx context.Context) ([]Group, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		fmt.Sprintf("%s/organizations/%s/groups", c.baseURL, c.orgName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Groups []Group `json:"groups"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+	return resp.Groups, nil
+}
+
+// GetGroup returns the group with the given name.
+//
+// If the group does not exist, the returned error matches ErrNotFound.
+func (c *Client) GetGroup(ctx context.Context, groupName string) (*Group, error) {
+	return c.doGroup(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf(
+			"%s/organizations/%s/groups/%s",
+			c.baseURL, c.orgName, groupName,
+		),
+		"get group "+groupName,
+	)
+}
+
+// DeleteGroup deletes the group with the given name and returns it.
+//
+// All databases in the group are deleted along with it.
+func (c *Client) DeleteGroup(
+	ctx context.Context,
+	groupName string,
+) (*Group, error) {
+	return c.doGroup(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf(
+			"%s/organizations/%s/groups/%s",
+			c.baseURL, c.orgName, groupName,
+		),
+		"delete group "+groupName,
+	)
+}
+
+// AddGroupLocation adds a replica location to the group with the given name.
+func (c *Client) AddGroupLocation(
+	ctx context.Context,
+	groupName string,
+	location Location,
+) (*Group, error) {
+	err := c.validate(groupLocation{Location: location})
+	if err != nil {
+		return nil, err
+	}
+	return c.doGroup(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf(
+			"%s/organizations/%s/groups/%s/locations/%s",
+			c.baseURL, c.orgName, groupName, location,
+		),
+		fmt.Sprintf("add location %s to group %s", location, groupName),
+	)
+}
+
+// RemoveGroupLocation removes a replica location from the group with the
+// given name.
+func (c *Client) RemoveGroupLocation(
+	ctx context.Context,
+	groupName string,
+	location Location,
+) (*Group, error) {
+	err := c.validate(groupLocation{Location: location})
+	if err != nil {
+		return nil, err
+	}
+	return c.doGroup(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf(
+			"%s/organizations/%s/groups/%s/locations/%s",
+			c.baseURL, c.orgName, groupName, location,
+		),
+		fmt.Sprintf("remove location %s from group %s", location, groupName),
+	)
+}
+
+// CreateGroupToken creates a token for every database of the group with the
+// given name with an optional given expiration and authorization.
+func (c *Client) CreateGroupToken(
+	ctx context.Context,
+	groupName string,
+	opts ...newDbTokenOpt,
+) (string, error) {
+	token, err := c.createToken(
+		ctx,
+		fmt.Sprintf(
+			"%s/organizations/%s/groups/%s/auth/tokens",
+			c.baseURL, c.orgName, groupName,
+		),
+		opts,
+	)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed to create token for group %s: %w",
+			groupName, err,
+		)
+	}
+	return token, nil
+}
+
+// InvalidateGroupTokens invalidates every token of the group with the given
+// name, including the tokens of its databases.
+func (c *Client) InvalidateGroupTokens(ctx context.Context, groupName string) error {
+	err := c.invalidateTokens(
+		ctx,
+		fmt.Sprintf(
+			"%s/organizations/%s/groups/%s/auth/rotate",
+			c.baseURL, c.orgName, groupName,
+		),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to invalidate tokens of group %s: %w",
+			groupName, err,
+		)
+	}
+	return nil
+}
+
+// doGroup sends a body-less request to a group endpoint and decodes the
+// group returned by the API.
+func (c *Client) doGroup(
+	ctx context.Context,
+	method, uri, action string,
+) (*Group, error) {
+	req, err := builders.NewRequest(ctx, c.header, method, uri)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Group Group `json:"group"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s: %w", action, err)
+	}
+	return &resp.Group, nil
+}
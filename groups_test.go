@@ -0,0 +1,56 @@
+package dbpu
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupLocation(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"group":{"name":"default","locations":["ams","lhr"]}}`))
+	}))
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	group, err := c.AddGroupLocation(ctx, "default", "lhr")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ams", "lhr"}, group.Locations)
+	_, err = c.RemoveGroupLocation(ctx, "default", "lhr")
+	require.NoError(t, err)
+
+	_, err = c.AddGroupLocation(ctx, "default", "Not A Region")
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.Equal(t, "location", verr.Fields[0].Field)
+	assert.Equal(t, "turso_location", verr.Fields[0].Rule)
+
+	assert.Equal(t, []string{
+		"POST /organizations/org/groups/default/locations/lhr",
+		"DELETE /organizations/org/groups/default/locations/lhr",
+	}, requests)
+}
+
+func TestGroupTokens(t *testing.T) {
+	srv, requests, _ := recordingServer(t, `{"jwt":"group-token"}`)
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	token, err := c.CreateGroupToken(ctx, "default", WithAuthorization("read-only"))
+	require.NoError(t, err)
+	assert.Equal(t, "group-token", token)
+	require.NoError(t, c.InvalidateGroupTokens(ctx, "default"))
+	assert.Equal(t, []string{
+		"POST /organizations/org/groups/default/auth/tokens?authorization=read-only",
+		"POST /organizations/org/groups/default/auth/rotate",
+	}, *requests)
+}
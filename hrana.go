@@ -0,0 +1,556 @@
+package dbpu
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrConnClosed is returned when a statement is executed on a closed Conn.
+var ErrConnClosed = errors.New("dbpu: connection closed")
+
+type (
+	connOpt func(*Conn)
+
+	// Conn is a connection to a libsql database speaking the Hrana over HTTP
+	// protocol.
+	//
+	// A Conn holds a stream on the server so that statements executed one
+	// after the other share the same session (e.g., transactions and
+	// temporary tables). A failing statement keeps the stream, but if a
+	// request fails (e.g., the stream expired) it is dropped and the next
+	// request opens a new one without the session state. Requests are
+	// serialized; a Conn is safe for concurrent use.
+	Conn struct {
+		client  *http.Client
+		url     string
+		token   string
+		version int
+
+		mu      sync.Mutex
+		baton   *string
+		baseURL string
+		closed  bool
+	}
+
+	// Statement is a SQL statement with its arguments.
+	Statement struct {
+		// SQL is the SQL of the statement.
+		SQL string
+		// Args are the positional arguments of the statement (?, ?1).
+		Args []any
+		// NamedArgs are the named arguments of the statement (:a, @a, $a),
+		// keyed by name including their prefix.
+		NamedArgs map[string]any
+	}
+
+	// Column is a column of the result of a statement.
+	Column struct {
+		Name     string  `json:"name"`
+		Decltype *string `json:"decltype"`
+	}
+
+	// Result is the result of a statement.
+	//
+	// Values are mapped to nil, int64, float64, string or []byte.
+	Result struct {
+		Columns          []Column
+		Rows             [][]any
+		AffectedRowCount int64
+		// LastInsertRowID is the rowid of the last inserted row, nil if the
+		// statement inserted none.
+		LastInsertRowID *int64
+	}
+
+	// SQLError is an error returned by the database for a statement.
+	SQLError struct {
+		Message string `json:"message"`
+		Code    string `json:"code"`
+		// Step is the index of the failing statement of a batch.
+		Step int `json:"-"`
+	}
+
+	hranaValue struct {
+		Type   string          `json:"type"`
+		Value  json.RawMessage `json:"value,omitempty"`
+		Base64 string          `json:"base64,omitempty"`
+	}
+
+	hranaNamedArg struct {
+		Name  string     `json:"name"`
+		Value hranaValue `json:"value"`
+	}
+
+	hranaStmt struct {
+		SQL       string          `json:"sql"`
+		Args      []hranaValue    `json:"args,omitempty"`
+		NamedArgs []hranaNamedArg `json:"named_args,omitempty"`
+		WantRows  bool            `json:"want_rows"`
+	}
+
+	hranaCondition struct {
+		Type string `json:"type"`
+		Step int    `json:"step"`
+	}
+
+	hranaBatchStep struct {
+		Condition *hranaCondition `json:"condition,omitempty"`
+		Stmt      hranaStmt       `json:"stmt"`
+	}
+
+	hranaRequest struct {
+		Type  string     `json:"type"`
+		Stmt  *hranaStmt `json:"stmt,omitempty"`
+		Batch *struct {
+			Steps []hranaBatchStep `json:"steps"`
+		} `json:"batch,omitempty"`
+	}
+
+	hranaPipelineRequest struct {
+		Baton    *string        `json:"baton"`
+		Requests []hranaRequest `json:"requests"`
+	}
+
+	hranaStmtResult struct {
+		Cols             []Column       `json:"cols"`
+		Rows             [][]hranaValue `json:"rows"`
+		AffectedRowCount int64          `json:"affected_row_count"`
+		LastInsertRowID  *string        `json:"last_insert_rowid"`
+	}
+
+	hranaPipelineResult struct {
+		Type     string    `json:"type"`
+		Error    *SQLError `json:"error"`
+		Response *struct {
+			Type   string          `json:"type"`
+			Result json.RawMessage `json:"result"`
+		} `json:"response"`
+	}
+
+	hranaPipelineResponse struct {
+		Baton   *string               `json:"baton"`
+		BaseURL *string               `json:"base_url"`
+		Results []hranaPipelineResult `json:"results"`
+	}
+
+	hranaBatchResult struct {
+		StepResults []*hranaStmtResult `json:"step_results"`
+		StepErrors  []*SQLError        `json:"step_errors"`
+	}
+)
+
+// WithConnURL sets the URL of the database, defaulting to the https URL of
+// its hostname.
+func WithConnURL(url string) func(*Conn) {
+	return func(c *Conn) { c.url = url }
+}
+
+// WithConnHTTPClient sets the HTTP client of the Conn.
+func WithConnHTTPClient(client *http.Client) func(*Conn) {
+	return func(c *Conn) { c.client = client }
+}
+
+// WithHranaVersion sets the version of the Hrana protocol (2 or 3),
+// defaulting to 3.
+func WithHranaVersion(version int) func(*Conn) {
+	return func(c *Conn) { c.version = version }
+}
+
+// NewConn returns a new connection to the given database authenticated with
+// the given database token.
+func NewConn(db Database, token string, opts ...connOpt) *Conn {
+	c := &Conn{
+		client:  http.DefaultClient,
+		url:     "https://" + db.Hostname,
+		token:   token,
+		version: 3,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Stmt returns a statement with the given positional arguments.
+func Stmt(sql string, args ...any) Statement {
+	return Statement{SQL: sql, Args: args}
+}
+
+// NamedStmt returns a statement with the given named arguments.
+func NamedStmt(sql string, args map[string]any) Statement {
+	return Statement{SQL: sql, NamedArgs: args}
+}
+
+// Execute executes the statement.
+func (c *Conn) Execute(ctx context.Context, stmt Statement) (*Result, error) {
+	hStmt, err := stmt.encode()
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.pipeline(ctx, hranaRequest{Type: "execute", Stmt: &hStmt})
+	if err != nil {
+		return nil, err
+	}
+	var result hranaStmtResult
+	err = results[0].decode("execute", &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.decode()
+}
+
+// Batch executes the statements in order, stopping at the first failing
+// one.
+//
+// The returned results hold a result per executed statement. If a statement
+// fails, the returned error is a *SQLError with the index of the statement.
+func (c *Conn) Batch(ctx context.Context, stmts ...Statement) ([]*Result, error) {
+	req := hranaRequest{Type: "batch", Batch: &struct {
+		Steps []hranaBatchStep `json:"steps"`
+	}{}}
+	for i, stmt := range stmts {
+		hStmt, err := stmt.encode()
+		if err != nil {
+			return nil, fmt.Errorf("statement %d: %w", i, err)
+		}
+		step := hranaBatchStep{Stmt: hStmt}
+		if i > 0 {
+			step.Condition = &hranaCondition{Type: "ok", Step: i - 1}
+		}
+		req.Batch.Steps = append(req.Batch.Steps, step)
+	}
+	results, err := c.pipeline(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var batch hranaBatchResult
+	err = results[0].decode("batch", &batch)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Result, 0, len(stmts))
+	for i, stepResult := range batch.StepResults {
+		if i < len(batch.StepErrors) && batch.StepErrors[i] != nil {
+			stepErr := *batch.StepErrors[i]
+			stepErr.Step = i
+			return out, &stepErr
+		}
+		if stepResult == nil {
+			break
+		}
+		result, err := stepResult.decode()
+		if err != nil {
+			return out, err
+		}
+		out = append(out, result)
+	}
+	return out, nil
+}
+
+// Close closes the stream of the Conn on the server.
+func (c *Conn) Close(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	if c.baton == nil {
+		return nil
+	}
+	_, err := c.send(ctx, hranaPipelineRequest{
+		Baton:    c.baton,
+		Requests: []hranaRequest{{Type: "close"}},
+	})
+	c.reset()
+	return err
+}
+
+// pipeline sends the request on the stream of the Conn and returns its
+// result.
+func (c *Conn) pipeline(
+	ctx context.Context,
+	req hranaRequest,
+) ([]hranaPipelineResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrConnClosed
+	}
+	resp, err := c.send(ctx, hranaPipelineRequest{
+		Baton:    c.baton,
+		Requests: []hranaRequest{req},
+	})
+	if err != nil {
+		// The stream may have expired or been rejected; open a new one on
+		// the next request rather than resending a dead baton.
+		c.reset()
+		return nil, err
+	}
+	if len(resp.Results) != 1 {
+		c.reset()
+		return nil, fmt.Errorf(
+			"hrana: expected 1 result, got %d",
+			len(resp.Results),
+		)
+	}
+	// A failed statement does not close the stream: the server keeps it,
+	// along with any open transaction, as long as it returns a baton.
+	if resp.Baton == nil {
+		c.reset()
+		return resp.Results, nil
+	}
+	c.baton = resp.Baton
+	if resp.BaseURL != nil && *resp.BaseURL != "" {
+		c.baseURL = *resp.BaseURL
+	}
+	return resp.Results, nil
+}
+
+// reset forgets the stream of the Conn so that the next request opens a new
+// one.
+func (c *Conn) reset() {
+	c.baton = nil
+	c.baseURL = ""
+}
+
+// send posts the pipeline request to the database.
+func (c *Conn) send(
+	ctx context.Context,
+	pipeline hranaPipelineRequest,
+) (*hranaPipelineResponse, error) {
+	body, err := json.Marshal(pipeline)
+	if err != nil {
+		return nil, err
+	}
+	base := c.url
+	if c.baseURL != "" {
+		base = c.baseURL
+	}
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/v%d/pipeline", base, c.version),
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if isFailureStatusCode(res) {
+		var errRes struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(res.Body).Decode(&errRes)
+		return nil, fmt.Errorf("hrana: %w", &RequestError{
+			HTTPStatusCode: res.StatusCode,
+			Err:            errors.New(errRes.Message),
+		})
+	}
+	var resp hranaPipelineResponse
+	err = json.NewDecoder(res.Body).Decode(&resp)
+	if err != nil {
+		return nil, fmt.Errorf("hrana: failed to decode response: %w", err)
+	}
+	return &resp, nil
+}
+
+// decode decodes the result of a pipeline request of the given type.
+func (r hranaPipelineResult) decode(typ string, v any) error {
+	switch {
+	case r.Type == "error" && r.Error != nil:
+		return r.Error
+	case r.Type != "ok" || r.Response == nil:
+		return fmt.Errorf("hrana: unexpected result type %q", r.Type)
+	case r.Response.Type != typ:
+		return fmt.Errorf(
+			"hrana: expected %s response, got %q",
+			typ, r.Response.Type,
+		)
+	}
+	return json.Unmarshal(r.Response.Result, v)
+}
+
+// encode encodes the statement for the Hrana protocol.
+func (s Statement) encode() (hranaStmt, error) {
+	stmt := hranaStmt{SQL: s.SQL, WantRows: true}
+	for i, arg := range s.Args {
+		v, err := encodeValue(arg)
+		if err != nil {
+			return stmt, fmt.Errorf("argument %d: %w", i+1, err)
+		}
+		stmt.Args = append(stmt.Args, v)
+	}
+	for name, arg := range s.NamedArgs {
+		v, err := encodeValue(arg)
+		if err != nil {
+			return stmt, fmt.Errorf("argument %s: %w", name, err)
+		}
+		stmt.NamedArgs = append(stmt.NamedArgs, hranaNamedArg{
+			Name:  name,
+			Value: v,
+		})
+	}
+	return stmt, nil
+}
+
+// decode decodes the result of a statement.
+func (r *hranaStmtResult) decode() (*Result, error) {
+	result := &Result{
+		Columns:          r.Cols,
+		Rows:             make([][]any, 0, len(r.Rows)),
+		AffectedRowCount: r.AffectedRowCount,
+	}
+	for _, row := range r.Rows {
+		values := make([]any, 0, len(row))
+		for _, v := range row {
+			value, err := decodeValue(v)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	if r.LastInsertRowID != nil {
+		id, err := strconv.ParseInt(*r.LastInsertRowID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("hrana: invalid last_insert_rowid: %w", err)
+		}
+		result.LastInsertRowID = &id
+	}
+	return result, nil
+}
+
+// encodeValue encodes a Go value as a Hrana value.
+func encodeValue(v any) (hranaValue, error) {
+	integer := func(i int64) hranaValue {
+		return hranaValue{
+			Type:  "integer",
+			Value: json.RawMessage(strconv.Quote(strconv.FormatInt(i, 10))),
+		}
+	}
+	switch v := v.(type) {
+	case nil:
+		return hranaValue{Type: "null"}, nil
+	case int:
+		return integer(int64(v)), nil
+	case int8:
+		return integer(int64(v)), nil
+	case int16:
+		return integer(int64(v)), nil
+	case int32:
+		return integer(int64(v)), nil
+	case int64:
+		return integer(v), nil
+	case uint:
+		return encodeUint(uint64(v))
+	case uint8:
+		return integer(int64(v)), nil
+	case uint16:
+		return integer(int64(v)), nil
+	case uint32:
+		return integer(int64(v)), nil
+	case uint64:
+		return encodeUint(v)
+	case bool:
+		if v {
+			return integer(1), nil
+		}
+		return integer(0), nil
+	case float32:
+		return encodeFloat(float64(v))
+	case float64:
+		return encodeFloat(v)
+	case string:
+		value, err := json.Marshal(v)
+		return hranaValue{Type: "text", Value: value}, err
+	case []byte:
+		return hranaValue{
+			Type:   "blob",
+			Base64: base64.StdEncoding.EncodeToString(v),
+		}, nil
+	}
+	return hranaValue{}, fmt.Errorf("hrana: unsupported value type %T", v)
+}
+
+func encodeUint(v uint64) (hranaValue, error) {
+	if v > math.MaxInt64 {
+		return hranaValue{}, fmt.Errorf("hrana: integer %d overflows int64", v)
+	}
+	return hranaValue{
+		Type:  "integer",
+		Value: json.RawMessage(strconv.Quote(strconv.FormatUint(v, 10))),
+	}, nil
+}
+
+func encodeFloat(v float64) (hranaValue, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return hranaValue{}, fmt.Errorf("hrana: float %v is not finite", v)
+	}
+	value, err := json.Marshal(v)
+	return hranaValue{Type: "float", Value: value}, err
+}
+
+// decodeValue decodes a Hrana value into a Go value.
+func decodeValue(v hranaValue) (any, error) {
+	switch v.Type {
+	case "null":
+		return nil, nil
+	case "integer":
+		var s string
+		err := json.Unmarshal(v.Value, &s)
+		if err != nil {
+			return nil, fmt.Errorf("hrana: invalid integer: %w", err)
+		}
+		return strconv.ParseInt(s, 10, 64)
+	case "float":
+		var f float64
+		err := json.Unmarshal(v.Value, &f)
+		if err != nil {
+			return nil, fmt.Errorf("hrana: invalid float: %w", err)
+		}
+		return f, nil
+	case "text":
+		var s string
+		err := json.Unmarshal(v.Value, &s)
+		if err != nil {
+			return nil, fmt.Errorf("hrana: invalid text: %w", err)
+		}
+		return s, nil
+	case "blob":
+		// Servers may omit the padding of the base64 encoding.
+		blob, err := base64.RawStdEncoding.DecodeString(
+			strings.TrimRight(v.Base64, "="),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("hrana: invalid blob: %w", err)
+		}
+		return blob, nil
+	}
+	return nil, fmt.Errorf("hrana: unknown value type %q", v.Type)
+}
+
+// Error implements the error interface on SQLError.
+func (e *SQLError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("sql error (%s): %s", e.Code, e.Message)
+	}
+	return "sql error: " + e.Message
+}
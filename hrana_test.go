@@ -0,0 +1,162 @@
+package dbpu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConn_Execute(t *testing.T) {
+	var got []hranaPipelineRequest
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v2/pipeline", r.URL.Path)
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			var req hranaPipelineRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			got = append(got, req)
+			if req.Requests[0].Type == "close" {
+				_, _ = w.Write([]byte(`{"baton":null,"results":[{"type":"ok","response":{"type":"close"}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"baton":"b1","results":[{"type":"ok","response":{"type":"execute","result":{
+				"cols":[{"name":"i"},{"name":"f"},{"name":"t"},{"name":"b"},{"name":"n"}],
+				"rows":[[{"type":"integer","value":"9007199254740993"},{"type":"float","value":1.5},{"type":"text","value":"hi"},{"type":"blob","base64":"AQI"},{"type":"null"}]],
+				"affected_row_count":1,"last_insert_rowid":"7"}}}]}`))
+		},
+	))
+	defer srv.Close()
+
+	conn := NewConn(
+		Database{Hostname: "db.turso.io"}, "token",
+		WithConnURL(srv.URL), WithHranaVersion(2),
+	)
+	res, err := conn.Execute(context.Background(), Stmt(
+		"SELECT ?, ?, ?, ?, ?", int64(9007199254740993), 1.5, "hi", []byte{1, 2}, nil,
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []any{int64(9007199254740993), 1.5, "hi", []byte{1, 2}, nil}, res.Rows[0])
+	assert.Equal(t, int64(1), res.AffectedRowCount)
+	require.NotNil(t, res.LastInsertRowID)
+	assert.Equal(t, int64(7), *res.LastInsertRowID)
+
+	_, err = conn.Execute(context.Background(), NamedStmt(
+		"SELECT :a", map[string]any{":a": true},
+	))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close(context.Background()))
+
+	require.Len(t, got, 3)
+	assert.Nil(t, got[0].Baton)
+	args := got[0].Requests[0].Stmt.Args
+	assert.Equal(t, "integer", args[0].Type)
+	assert.JSONEq(t, `"9007199254740993"`, string(args[0].Value))
+	assert.Equal(t, "float", args[1].Type)
+	assert.Equal(t, "text", args[2].Type)
+	assert.Equal(t, "AQI=", args[3].Base64)
+	assert.Equal(t, "null", args[4].Type)
+	require.NotNil(t, got[1].Baton)
+	assert.Equal(t, "b1", *got[1].Baton)
+	named := got[1].Requests[0].Stmt.NamedArgs
+	assert.Equal(t, ":a", named[0].Name)
+	assert.JSONEq(t, `"1"`, string(named[0].Value.Value))
+	assert.Equal(t, "close", got[2].Requests[0].Type)
+
+	_, err = conn.Execute(context.Background(), Stmt("SELECT 1"))
+	assert.ErrorIs(t, err, ErrConnClosed)
+}
+
+func TestConn_Batch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v3/pipeline", r.URL.Path)
+			var req hranaPipelineRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			steps := req.Requests[0].Batch.Steps
+			require.Len(t, steps, 3)
+			assert.Nil(t, steps[0].Condition)
+			assert.Equal(t, &hranaCondition{Type: "ok", Step: 1}, steps[2].Condition)
+			_, _ = w.Write([]byte(`{"baton":"b","results":[{"type":"ok","response":{"type":"batch","result":{
+				"step_results":[{"cols":[],"rows":[],"affected_row_count":1},null,null],
+				"step_errors":[null,{"message":"no such table: t","code":"SQLITE_ERROR"},null]}}}]}`))
+		},
+	))
+	defer srv.Close()
+
+	conn := NewConn(Database{}, "token", WithConnURL(srv.URL))
+	res, err := conn.Batch(context.Background(),
+		Stmt("INSERT INTO a VALUES (?)", 1),
+		Stmt("INSERT INTO t VALUES (?)", 2),
+		Stmt("SELECT 1"),
+	)
+	require.Len(t, res, 1)
+	var sqlErr *SQLError
+	require.True(t, errors.As(err, &sqlErr))
+	assert.Equal(t, 1, sqlErr.Step)
+	assert.Equal(t, "SQLITE_ERROR", sqlErr.Code)
+}
+
+func TestConn_StreamOnError(t *testing.T) {
+	var batons []*string
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var req hranaPipelineRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			batons = append(batons, req.Baton)
+			switch len(batons) {
+			case 2:
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"stream expired"}`))
+				return
+			case 3:
+				_, _ = w.Write([]byte(`{"baton":"b3","results":[{"type":"error","error":{"message":"syntax error","code":"SQLITE_ERROR"}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"baton":"b","base_url":"` + "http://" + r.Host + `","results":[{"type":"ok","response":{"type":"execute","result":{"cols":[],"rows":[],"affected_row_count":0}}}]}`))
+		},
+	))
+	defer srv.Close()
+
+	conn := NewConn(Database{}, "token", WithConnURL(srv.URL))
+	ctx := context.Background()
+	_, err := conn.Execute(ctx, Stmt("SELECT 1"))
+	require.NoError(t, err)
+	_, err = conn.Execute(ctx, Stmt("SELECT 1"))
+	var reqErr *RequestError
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, http.StatusBadRequest, reqErr.HTTPStatusCode)
+	_, err = conn.Execute(ctx, Stmt("SELEC 1"))
+	var sqlErr *SQLError
+	require.ErrorAs(t, err, &sqlErr)
+	_, err = conn.Execute(ctx, Stmt("SELECT 1"))
+	require.NoError(t, err)
+
+	require.Len(t, batons, 4)
+	assert.Nil(t, batons[0])
+	require.NotNil(t, batons[1])
+	assert.Equal(t, "b", *batons[1])
+	assert.Nil(t, batons[2])
+	require.NotNil(t, batons[3])
+	assert.Equal(t, "b3", *batons[3])
+}
+
+func TestEncodeValue_Unsupported(t *testing.T) {
+	_, err := encodeValue(struct{}{})
+	assert.Error(t, err)
+	_, err = encodeValue(uint64(1 << 63))
+	assert.Error(t, err)
+}
+
+func TestDecodeValue_Blob(t *testing.T) {
+	for _, encoded := range []string{"AQI", "AQI="} {
+		got, err := decodeValue(hranaValue{Type: "blob", Base64: encoded})
+		require.NoError(t, err, encoded)
+		assert.Equal(t, []byte{1, 2}, got, encoded)
+	}
+}
@@ -0,0 +1,133 @@
+package dbpu
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/conneroisu/dbpu/internal/builders"
+)
+
+// Instance types of a database.
+const (
+	// InstancePrimary is the type of the primary instance of a database.
+	InstancePrimary = "primary"
+	// InstanceReplica is the type of the replicas of a database.
+	InstanceReplica = "replica"
+)
+
+// Instance is an instance of a database running in a location.
+type Instance struct {
+	ID       string   `json:"uuid"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Region   Location `json:"region"`
+	Hostname string   `json:"hostname"`
+}
+
+// ListInstances lists the instances of the database with the given name.
+func (c *Client) ListInstances(
+	ctx context.Context,
+	dbName string,
+) ([]Instance, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		fmt.Sprintf(
+			"%s/organizations/%s/databases/%s/instances",
+			c.baseURL, c.orgName, dbName,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Instances []Instance `json:"instances"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to list instances of database %s: %w",
+			dbName, err,
+		)
+	}
+	return resp.Instances, nil
+}
+
+// GetInstance returns the instance with the given name of the database with
+// the given name.
+func (c *Client) GetInstance(
+	ctx context.Context,
+	dbName, instanceName string,
+) (*Instance, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		fmt.Sprintf(
+			"%s/organizations/%s/databases/%s/instances/%s",
+			c.baseURL, c.orgName, dbName, instanceName,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Instance Instance `json:"instance"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get instance %s of database %s: %w",
+			instanceName, dbName, err,
+		)
+	}
+	return &resp.Instance, nil
+}
+
+// ClosestInstance returns the instance of the database with the given name
+// running in the location reported by ClosestLocation, or its primary
+// instance if it has no instance there.
+func (c *Client) ClosestInstance(
+	ctx context.Context,
+	dbName string,
+) (*Instance, error) {
+	closest, err := c.ClosestLocation(ctx)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := c.ListInstances(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	instance := InstanceIn(instances, Location(closest.Server))
+	if instance == nil {
+		return nil, fmt.Errorf(
+			"database %s has no instances: %w",
+			dbName, ErrNotFound,
+		)
+	}
+	return instance, nil
+}
+
+// InstanceIn returns the instance running in the given location, or the
+// primary instance if none does.
+//
+// If no instance is marked as primary, the first one is returned instead. It
+// returns nil if there are no instances.
+func InstanceIn(instances []Instance, location Location) *Instance {
+	var primary *Instance
+	for i := range instances {
+		if instances[i].Region == location {
+			return &instances[i]
+		}
+		if instances[i].Type == InstancePrimary && primary == nil {
+			primary = &instances[i]
+		}
+	}
+	if primary == nil && len(instances) > 0 {
+		primary = &instances[0]
+	}
+	return primary
+}
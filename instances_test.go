@@ -0,0 +1,32 @@
+package dbpu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstanceIn(t *testing.T) {
+	instances := []Instance{
+		{Name: "replica-lhr", Type: InstanceReplica, Region: "lhr"},
+		{Name: "primary-ams", Type: InstancePrimary, Region: "ams"},
+		{Name: "replica-ord", Type: InstanceReplica, Region: "ord"},
+	}
+	tests := []struct {
+		instances []Instance
+		location  Location
+		want      string
+	}{
+		{instances, "ord", "replica-ord"},
+		{instances, "ams", "primary-ams"},
+		{instances, "syd", "primary-ams"},
+		{[]Instance{instances[0], instances[2]}, "syd", "replica-lhr"},
+	}
+	for _, tt := range tests {
+		got := InstanceIn(tt.instances, tt.location)
+		if assert.NotNil(t, got, tt.location) {
+			assert.Equal(t, tt.want, got.Name, tt.location)
+		}
+	}
+	assert.Nil(t, InstanceIn(nil, "ams"))
+}
@@ -2,10 +2,67 @@ package tursoerr
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 )
 
+var (
+	// ErrNotFound is matched by errors.Is when the Turso API reports that the
+	// requested resource does not exist.
+	ErrNotFound = errors.New("tursoerr: resource not found")
+	// ErrAlreadyExists is matched by errors.Is when the Turso API reports that
+	// the resource to create already exists.
+	ErrAlreadyExists = errors.New("tursoerr: resource already exists")
+	// ErrUnauthorized is matched by errors.Is when the Turso API rejects the
+	// token of the request.
+	ErrUnauthorized = errors.New("tursoerr: unauthorized")
+	// ErrRateLimited is matched by errors.Is when the Turso API rejects the
+	// request because of its rate limits.
+	ErrRateLimited = errors.New("tursoerr: rate limited")
+	// ErrQuotaExceeded is matched by errors.Is when the Turso API rejects the
+	// request because a quota of the organization is exceeded.
+	ErrQuotaExceeded = errors.New("tursoerr: quota exceeded")
+)
+
+// codeSentinels maps the codes of the Turso API to sentinel errors.
+var codeSentinels = map[string]error{
+	"not_found":      ErrNotFound,
+	"already_exists": ErrAlreadyExists,
+	"conflict":       ErrAlreadyExists,
+	"unauthorized":   ErrUnauthorized,
+	"forbidden":      ErrUnauthorized,
+	"rate_limited":   ErrRateLimited,
+	"quota_exceeded": ErrQuotaExceeded,
+	"limit_exceeded": ErrQuotaExceeded,
+}
+
+// Sentinel returns the sentinel error matching the given HTTP status code and
+// API code, or nil if there is none.
+//
+// The API code takes precedence over the status code.
+func Sentinel(statusCode int, code any) error {
+	if c, ok := code.(string); ok {
+		if err, ok := codeSentinels[strings.ToLower(c)]; ok {
+			return err
+		}
+	}
+	switch statusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrAlreadyExists
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusPaymentRequired, http.StatusInsufficientStorage:
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
 type (
 	// ErrorResponse is the response returned by the Turso API.
 	ErrorResponse struct {
@@ -45,8 +102,19 @@ func (e *APIError) Error() string {
 	return e.Message
 }
 
+// Is reports whether the APIError matches the target sentinel error.
+func (e *APIError) Is(target error) bool {
+	sentinel := Sentinel(e.HTTPStatusCode, e.Code)
+	return sentinel != nil && target == sentinel
+}
+
 // UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// The Turso API may return the error as a bare message string.
 func (e *APIError) UnmarshalJSON(data []byte) (err error) {
+	if err = json.Unmarshal(data, &e.Message); err == nil {
+		return nil
+	}
 	var rawMap map[string]json.RawMessage
 	err = json.Unmarshal(data, &rawMap)
 	if err != nil {
@@ -82,9 +150,25 @@ func (e *APIError) UnmarshalJSON(data []byte) (err error) {
 	return json.Unmarshal(rawMap["code"], &e.Code)
 }
 
+// Error method implements the error interface on ErrRequest.
 func (e *ErrRequest) Error() string {
 	if e.Err == nil {
-		return ""
+		return fmt.Sprintf(
+			"error, status code: %d, %s",
+			e.HTTPStatusCode,
+			http.StatusText(e.HTTPStatusCode),
+		)
 	}
 	return e.Err.Error()
 }
+
+// Unwrap returns the underlying error of the ErrRequest.
+func (e *ErrRequest) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the ErrRequest matches the target sentinel error.
+func (e *ErrRequest) Is(target error) bool {
+	sentinel := Sentinel(e.HTTPStatusCode, nil)
+	return sentinel != nil && target == sentinel
+}
@@ -0,0 +1,77 @@
+package tursoerr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input string
+		want  APIError
+	}{
+		{
+			`{"error":"database already exists"}`,
+			APIError{Message: "database already exists"},
+		},
+		{
+			`{"error":{"message":"not found","code":"not_found"}}`,
+			APIError{Message: "not found", Code: "not_found"},
+		},
+		{
+			`{"error":{"message":["a","b"],"code":404}}`,
+			APIError{Message: "a, b", Code: 404},
+		},
+	}
+	for _, tt := range tests {
+		var res ErrorResponse
+		err := json.Unmarshal([]byte(tt.input), &res)
+		assert.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, *res.Error, tt.input)
+	}
+}
+
+func TestSentinel(t *testing.T) {
+	tests := []struct {
+		status int
+		code   any
+		want   error
+	}{
+		{http.StatusNotFound, nil, ErrNotFound},
+		{http.StatusConflict, nil, ErrAlreadyExists},
+		{http.StatusUnauthorized, nil, ErrUnauthorized},
+		{http.StatusForbidden, nil, ErrUnauthorized},
+		{http.StatusTooManyRequests, nil, ErrRateLimited},
+		{http.StatusPaymentRequired, nil, ErrQuotaExceeded},
+		{http.StatusBadRequest, "quota_exceeded", ErrQuotaExceeded},
+		{http.StatusBadRequest, 400, nil},
+		{http.StatusInternalServerError, nil, nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Sentinel(tt.status, tt.code), "%d %v", tt.status, tt.code)
+	}
+}
+
+func TestErrors_Is(t *testing.T) {
+	apiErr := fmt.Errorf("failed: %w", &APIError{
+		Message:        "database already exists",
+		HTTPStatusCode: http.StatusConflict,
+	})
+	assert.ErrorIs(t, apiErr, ErrAlreadyExists)
+	assert.NotErrorIs(t, apiErr, ErrNotFound)
+
+	reqErr := fmt.Errorf("failed: %w", &ErrRequest{
+		HTTPStatusCode: http.StatusNotFound,
+	})
+	assert.ErrorIs(t, reqErr, ErrNotFound)
+	assert.Equal(t, "failed: error, status code: 404, Not Found", reqErr.Error())
+
+	var target *ErrRequest
+	assert.True(t, errors.As(reqErr, &target))
+	assert.Equal(t, http.StatusNotFound, target.HTTPStatusCode)
+}
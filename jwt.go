@@ -0,0 +1,165 @@
+package dbpu
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidToken is matched by errors.Is when a token is not a well
+	// formed JWT.
+	ErrInvalidToken = errors.New("dbpu: invalid token")
+	// ErrTokenSignature is matched by errors.Is when the signature of a
+	// token does not match the given public key.
+	ErrTokenSignature = errors.New("dbpu: invalid token signature")
+)
+
+// Access levels of a token.
+const (
+	// AccessFullAccess is the access level of full-access tokens.
+	AccessFullAccess = "rw"
+	// AccessReadOnly is the access level of read-only tokens.
+	AccessReadOnly = "ro"
+)
+
+type (
+	parseTokenOpt func(*parseTokenConfig)
+
+	// parseTokenConfig is a configuration for parsing a token.
+	parseTokenConfig struct {
+		publicKey ed25519.PublicKey
+	}
+
+	// TokenClaims are the claims of a database or group token.
+	TokenClaims struct {
+		// Access is the access level of the token (rw or ro), empty for
+		// tokens only granting fine-grained permissions.
+		Access string
+		// IssuedAt is the time the token was minted.
+		IssuedAt time.Time
+		// ExpiresAt is the time the token expires, zero if it never expires.
+		ExpiresAt time.Time
+		// DatabaseID is the ID of the database of a database token.
+		DatabaseID string
+		// GroupID is the ID of the group of a group token.
+		GroupID string
+		// Permissions are the fine-grained permissions of the token.
+		Permissions TokenPermissions
+	}
+
+	// TokenPermissions are the fine-grained permission claims of a token.
+	TokenPermissions struct {
+		// ReadOnly lists the databases the token can read.
+		ReadOnly *NamespaceClaim `json:"ro,omitempty"`
+		// ReadWrite lists the databases the token can read and write.
+		ReadWrite *NamespaceClaim `json:"rw,omitempty"`
+		// ReadAttach lists the databases the token can attach read-only.
+		ReadAttach *NamespaceClaim `json:"roa,omitempty"`
+	}
+
+	// NamespaceClaim lists the databases a permission claim applies to.
+	NamespaceClaim struct {
+		Namespaces []string `json:"ns"`
+	}
+
+	// rawTokenClaims are the claims of a token as encoded in its payload.
+	rawTokenClaims struct {
+		Access      string           `json:"a"`
+		IssuedAt    int64            `json:"iat"`
+		ExpiresAt   int64            `json:"exp"`
+		ID          string           `json:"id"`
+		GroupID     string           `json:"gid"`
+		Permissions TokenPermissions `json:"p"`
+	}
+)
+
+// WithPublicKey verifies the Ed25519 signature of the parsed token with the
+// given public key.
+func WithPublicKey(publicKey ed25519.PublicKey) func(*parseTokenConfig) {
+	return func(c *parseTokenConfig) { c.publicKey = publicKey }
+}
+
+// ParseDatabaseToken decodes the claims of a database or group token.
+//
+// The signature of the token is only verified if a public key is given;
+// otherwise the claims must not be trusted for authorization decisions.
+func ParseDatabaseToken(token string, opts ...parseTokenOpt) (*TokenClaims, error) {
+	config := parseTokenConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: expected 3 parts, got %d", ErrInvalidToken, len(parts))
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	err := decodeSegment(parts[0], &header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: header: %w", ErrInvalidToken, err)
+	}
+	var raw rawTokenClaims
+	err = decodeSegment(parts[1], &raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: claims: %w", ErrInvalidToken, err)
+	}
+	if config.publicKey != nil {
+		if header.Alg != "EdDSA" {
+			return nil, fmt.Errorf(
+				"%w: unexpected algorithm %q",
+				ErrTokenSignature, header.Alg,
+			)
+		}
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTokenSignature, err)
+		}
+		signed := token[:len(parts[0])+1+len(parts[1])]
+		if !ed25519.Verify(config.publicKey, []byte(signed), signature) {
+			return nil, ErrTokenSignature
+		}
+	}
+	claims := &TokenClaims{
+		Access:      raw.Access,
+		DatabaseID:  raw.ID,
+		GroupID:     raw.GroupID,
+		Permissions: raw.Permissions,
+	}
+	if raw.IssuedAt > 0 {
+		claims.IssuedAt = time.Unix(raw.IssuedAt, 0)
+	}
+	if raw.ExpiresAt > 0 {
+		claims.ExpiresAt = time.Unix(raw.ExpiresAt, 0)
+	}
+	return claims, nil
+}
+
+// ReadOnly reports whether the token only grants read access.
+func (c *TokenClaims) ReadOnly() bool {
+	return c.Access == AccessReadOnly
+}
+
+// Expires reports whether the token has an expiration.
+func (c *TokenClaims) Expires() bool {
+	return !c.ExpiresAt.IsZero()
+}
+
+// ExpiredAt reports whether the token is expired at the given time.
+func (c *TokenClaims) ExpiredAt(t time.Time) bool {
+	return c.Expires() && !t.Before(c.ExpiresAt)
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a JWT.
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
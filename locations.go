@@ -0,0 +1,159 @@
+package dbpu
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/conneroisu/dbpu/internal/builders"
+	"github.com/go-playground/validator/v10"
+)
+
+// Location is a turso region code such as "ams" or "aws-us-east-1".
+type Location string
+
+// String returns the region code of the location.
+func (l Location) String() string { return string(l) }
+
+// locationPattern matches well formed region codes such as "ams" or
+// "aws-us-east-1".
+var locationPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// defaultLocations is the catalog of locations known to dbpu before
+// ListLocations has been called.
+//
+// It is advisory: turso adds regions over time, so locations missing from it
+// are not rejected.
+var defaultLocations = map[Location]string{
+	"ams":                "Amsterdam, Netherlands",
+	"arn":                "Stockholm, Sweden",
+	"bog":                "Bogotá, Colombia",
+	"bos":                "Boston, Massachusetts (US)",
+	"cdg":                "Paris, France",
+	"den":                "Denver, Colorado (US)",
+	"dfw":                "Dallas, Texas (US)",
+	"ewr":                "Secaucus, NJ (US)",
+	"fra":                "Frankfurt, Germany",
+	"gdl":                "Guadalajara, Mexico",
+	"gig":                "Rio de Janeiro, Brazil",
+	"gru":                "São Paulo, Brazil",
+	"hkg":                "Hong Kong, Hong Kong",
+	"iad":                "Ashburn, Virginia (US)",
+	"jnb":                "Johannesburg, South Africa",
+	"lax":                "Los Angeles, California (US)",
+	"lhr":                "London, United Kingdom",
+	"mad":                "Madrid, Spain",
+	"mia":                "Miami, Florida (US)",
+	"nrt":                "Tokyo, Japan",
+	"ord":                "Chicago, Illinois (US)",
+	"otp":                "Bucharest, Romania",
+	"phx":                "Phoenix, Arizona (US)",
+	"qro":                "Querétaro, Mexico",
+	"scl":                "Santiago, Chile",
+	"sea":                "Seattle, Washington (US)",
+	"sin":                "Singapore, Singapore",
+	"sjc":                "San Jose, California (US)",
+	"syd":                "Sydney, Australia",
+	"waw":                "Warsaw, Poland",
+	"yul":                "Montreal, Canada",
+	"yyz":                "Toronto, Canada",
+	"aws-ap-northeast-1": "AWS AP NorthEast (Tokyo)",
+	"aws-ap-south-1":     "AWS AP South (Mumbai)",
+	"aws-eu-west-1":      "AWS EU West (Ireland)",
+	"aws-us-east-1":      "AWS US East (Virginia)",
+	"aws-us-west-2":      "AWS US West (Oregon)",
+}
+
+// locationCatalog is the set of locations a Client knows of.
+//
+// Until it is loaded from the turso API, any well formed region code is
+// accepted when validating configurations.
+type locationCatalog struct {
+	mu        sync.RWMutex
+	locations map[Location]string
+	loaded    bool
+}
+
+func newLocationCatalog() *locationCatalog {
+	locations := make(map[Location]string, len(defaultLocations))
+	for code, desc := range defaultLocations {
+		locations[code] = desc
+	}
+	return &locationCatalog{locations: locations}
+}
+
+func (l *locationCatalog) has(code Location) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, ok := l.locations[code]
+	return ok
+}
+
+func (l *locationCatalog) set(locations map[Location]string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.locations = locations
+	l.loaded = true
+}
+
+// valid reports whether the location is accepted by the catalog: a location
+// of the catalog once loaded from the turso API, a well formed region code
+// otherwise.
+func (l *locationCatalog) valid(code Location) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.loaded {
+		_, ok := l.locations[code]
+		return ok
+	}
+	return locationPattern.MatchString(string(code))
+}
+
+// validateLocation is the "turso_location" validation of a Client.
+func (l *locationCatalog) validateLocation(fl validator.FieldLevel) bool {
+	return l.valid(Location(fl.Field().String()))
+}
+
+// ListLocations returns the locations supported by the turso API keyed by
+// their region code with a human readable description as the value.
+//
+// The returned locations replace the catalog the Client uses to validate the
+// location of a Config: from then on, only these locations are accepted.
+func (c *Client) ListLocations(ctx context.Context) (map[Location]string, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		fmt.Sprintf("%s/locations", c.baseURL),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Locations map[Location]string `json:"locations"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+	if len(resp.Locations) > 0 {
+		c.locations.set(resp.Locations)
+	}
+	return resp.Locations, nil
+}
+
+// KnownLocations returns the region codes the Client knows of, sorted
+// alphabetically: the built-in catalog until ListLocations is called.
+func (c *Client) KnownLocations() []Location {
+	c.locations.mu.RLock()
+	defer c.locations.mu.RUnlock()
+	codes := make([]Location, 0, len(c.locations.locations))
+	for code := range c.locations.locations {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	return codes
+}
@@ -0,0 +1,34 @@
+package dbpu
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ValidateLocation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/locations", r.URL.Path)
+		_, _ = w.Write([]byte(`{"locations":{"ams":"Amsterdam","aws-us-east-2":"AWS US East (Ohio)"}}`))
+	}))
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+	config := func(location Location) Config {
+		return Config{Name: "db", Location: location, Group: "default"}
+	}
+
+	// The built-in catalog is advisory.
+	assert.NoError(t, c.validate(config("aws-us-east-2")))
+	assert.NoError(t, c.validate(config("zzz")))
+	assert.Error(t, c.validate(config("Not A Region")))
+
+	_, err := c.ListLocations(context.Background())
+	require.NoError(t, err)
+	assert.NoError(t, c.validate(config("aws-us-east-2")))
+	assert.Error(t, c.validate(config("zzz")))
+	assert.Equal(t, []Location{"ams", "aws-us-east-2"}, c.KnownLocations())
+}
@@ -0,0 +1,209 @@
+package dbpu
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxDatabaseNameLength is the maximum length of a turso database name.
+const MaxDatabaseNameLength = 64
+
+var (
+	// ErrInvalidName is matched by errors.Is when a database name does not
+	// follow the naming rules of turso.
+	ErrInvalidName = errors.New("dbpu: invalid database name")
+	// ErrNameCollision is matched by errors.Is when two tenants are given the
+	// same database name.
+	ErrNameCollision = errors.New("dbpu: database name collision")
+
+	// tenantEncoding is the reversible encoding of tenant IDs into database
+	// names. Lowercase base32 only uses characters allowed in names.
+	tenantEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").
+			WithPadding(base32.NoPadding)
+)
+
+type (
+	// NameStrategy maps a tenant ID, such as a user ID or an email, to a
+	// database name.
+	//
+	// Implementations must be deterministic so that every service derives the
+	// same name for the same tenant.
+	NameStrategy interface {
+		Name(tenantID string) (string, error)
+	}
+
+	// NameStrategyFunc is a function implementing NameStrategy.
+	NameStrategyFunc func(tenantID string) (string, error)
+
+	// SlugStrategy names databases by prefixing a slug of the tenant ID.
+	//
+	// Characters not allowed in database names are replaced by dashes, so
+	// distinct tenant IDs may collide.
+	SlugStrategy struct {
+		Prefix string
+	}
+
+	// HashStrategy names databases by prefixing a truncated SHA-256 hash of
+	// the tenant ID.
+	HashStrategy struct {
+		Prefix string
+		// Length is the number of hex characters of the hash to keep,
+		// defaulting to 16.
+		Length int
+	}
+
+	// Base32Strategy names databases by prefixing a lowercase base32
+	// encoding of the tenant ID, which can be decoded back with Tenant.
+	Base32Strategy struct {
+		Prefix string
+	}
+
+	// NameCollisionError is returned when two tenants map to the same
+	// database name.
+	NameCollisionError struct {
+		Name    string
+		Tenants [2]string
+	}
+)
+
+// Name calls f(tenantID).
+func (f NameStrategyFunc) Name(tenantID string) (string, error) {
+	return f(tenantID)
+}
+
+// Name returns the prefixed slug of the tenant ID.
+func (s SlugStrategy) Name(tenantID string) (string, error) {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(tenantID) {
+		if isNameRune(r) && r != '-' {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.TrimRight(b.String(), "-")
+	if slug == "" {
+		return "", fmt.Errorf("%w: tenant %q has no usable characters", ErrInvalidName, tenantID)
+	}
+	name := s.Prefix + slug
+	if len(name) > MaxDatabaseNameLength {
+		name = strings.TrimRight(name[:MaxDatabaseNameLength], "-")
+	}
+	return name, ValidateDatabaseName(name)
+}
+
+// Name returns the prefixed truncated hash of the tenant ID.
+func (s HashStrategy) Name(tenantID string) (string, error) {
+	length := s.Length
+	if length <= 0 {
+		length = 16
+	}
+	sum := sha256.Sum256([]byte(tenantID))
+	hash := hex.EncodeToString(sum[:])
+	if length > len(hash) {
+		length = len(hash)
+	}
+	name := s.Prefix + hash[:length]
+	return name, ValidateDatabaseName(name)
+}
+
+// Name returns the prefixed base32 encoding of the tenant ID.
+func (s Base32Strategy) Name(tenantID string) (string, error) {
+	if tenantID == "" {
+		return "", fmt.Errorf("%w: empty tenant", ErrInvalidName)
+	}
+	name := s.Prefix + tenantEncoding.EncodeToString([]byte(tenantID))
+	return name, ValidateDatabaseName(name)
+}
+
+// Tenant decodes the tenant ID from a database name returned by Name.
+func (s Base32Strategy) Tenant(name string) (string, error) {
+	encoded, ok := strings.CutPrefix(name, s.Prefix)
+	if !ok {
+		return "", fmt.Errorf("%w: %q lacks prefix %q", ErrInvalidName, name, s.Prefix)
+	}
+	tenantID, err := tenantEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q: %w", ErrInvalidName, name, err)
+	}
+	return string(tenantID), nil
+}
+
+// ValidateDatabaseName reports whether the name follows the naming rules of
+// turso: lowercase letters, digits and dashes, starting and ending with a
+// letter or digit, and at most MaxDatabaseNameLength characters.
+//
+// The returned error matches ErrInvalidName.
+func ValidateDatabaseName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("%w: empty name", ErrInvalidName)
+	case len(name) > MaxDatabaseNameLength:
+		return fmt.Errorf(
+			"%w: %q is longer than %d characters",
+			ErrInvalidName, name, MaxDatabaseNameLength,
+		)
+	case name[0] == '-' || name[len(name)-1] == '-':
+		return fmt.Errorf("%w: %q starts or ends with a dash", ErrInvalidName, name)
+	}
+	for _, r := range name {
+		if !isNameRune(r) {
+			return fmt.Errorf("%w: %q contains %q", ErrInvalidName, name, r)
+		}
+	}
+	return nil
+}
+
+// CheckCollisions names every tenant with the strategy and returns the
+// names keyed by tenant ID.
+//
+// If two tenants map to the same name, the returned error is a
+// *NameCollisionError.
+func CheckCollisions(
+	strategy NameStrategy,
+	tenantIDs ...string,
+) (map[string]string, error) {
+	names := make(map[string]string, len(tenantIDs))
+	tenants := make(map[string]string, len(tenantIDs))
+	for _, tenantID := range tenantIDs {
+		name, err := strategy.Name(tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := tenants[name]; ok && other != tenantID {
+			return nil, &NameCollisionError{
+				Name:    name,
+				Tenants: [2]string{other, tenantID},
+			}
+		}
+		tenants[name] = tenantID
+		names[tenantID] = name
+	}
+	return names, nil
+}
+
+// Error implements the error interface on NameCollisionError.
+func (e *NameCollisionError) Error() string {
+	return fmt.Sprintf(
+		"%s: tenants %q and %q both map to %q",
+		ErrNameCollision, e.Tenants[0], e.Tenants[1], e.Name,
+	)
+}
+
+// Is reports whether the target is ErrNameCollision.
+func (e *NameCollisionError) Is(target error) bool {
+	return target == ErrNameCollision
+}
+
+func isNameRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-'
+}
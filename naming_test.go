@@ -0,0 +1,92 @@
+package dbpu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugStrategy_Name(t *testing.T) {
+	tests := []struct {
+		strategy SlugStrategy
+		tenant   string
+		want     string
+	}{
+		{SlugStrategy{}, "alice", "alice"},
+		{SlugStrategy{Prefix: "u-"}, "Alice@Example.com", "u-alice-example-com"},
+		{SlugStrategy{}, "--a__b--", "a-b"},
+		{SlugStrategy{}, strings.Repeat("a", 70), strings.Repeat("a", 64)},
+	}
+	for _, tt := range tests {
+		got, err := tt.strategy.Name(tt.tenant)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+	_, err := SlugStrategy{}.Name("@@@")
+	assert.ErrorIs(t, err, ErrInvalidName)
+}
+
+func TestHashStrategy_Name(t *testing.T) {
+	s := HashStrategy{Prefix: "t-", Length: 8}
+	a, err := s.Name("alice@example.com")
+	assert.NoError(t, err)
+	b, err := s.Name("alice@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, a, b)
+	assert.Len(t, a, 10)
+
+	c, err := HashStrategy{}.Name("alice@example.com")
+	assert.NoError(t, err)
+	assert.Len(t, c, 16)
+}
+
+func TestBase32Strategy_RoundTrip(t *testing.T) {
+	s := Base32Strategy{Prefix: "u-"}
+	for _, tenant := range []string{
+		"alice@example.com",
+		"3f1c2a5e-8d1b-4c1e-9a2f-1b2c3d4e5f60",
+		"Ünïcode",
+	} {
+		name, err := s.Name(tenant)
+		assert.NoError(t, err)
+		assert.NoError(t, ValidateDatabaseName(name))
+		got, err := s.Tenant(name)
+		assert.NoError(t, err)
+		assert.Equal(t, tenant, got)
+	}
+	_, err := s.Name(strings.Repeat("x", 64))
+	assert.ErrorIs(t, err, ErrInvalidName)
+	_, err = s.Tenant("v-abc")
+	assert.ErrorIs(t, err, ErrInvalidName)
+}
+
+func TestValidateDatabaseName(t *testing.T) {
+	for _, name := range []string{"a", "tenant-1", strings.Repeat("a", 64)} {
+		assert.NoError(t, ValidateDatabaseName(name), name)
+	}
+	for _, name := range []string{
+		"",
+		"-a",
+		"a-",
+		"Tenant",
+		"a_b",
+		strings.Repeat("a", 65),
+	} {
+		assert.ErrorIs(t, ValidateDatabaseName(name), ErrInvalidName, name)
+	}
+}
+
+func TestCheckCollisions(t *testing.T) {
+	names, err := CheckCollisions(SlugStrategy{}, "alice", "bob", "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"alice": "alice", "bob": "bob"}, names)
+
+	_, err = CheckCollisions(SlugStrategy{}, "a.b", "a_b")
+	assert.ErrorIs(t, err, ErrNameCollision)
+	var collision *NameCollisionError
+	assert.True(t, errors.As(err, &collision))
+	assert.Equal(t, "a-b", collision.Name)
+	assert.Equal(t, [2]string{"a.b", "a_b"}, collision.Tenants)
+}
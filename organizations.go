@@ -0,0 +1,290 @@
+package dbpu
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/conneroisu/dbpu/internal/builders"
+)
+
+// Roles of the members of an organization.
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+)
+
+type (
+	// Organization is a turso organization.
+	Organization struct {
+		Name          string `json:"name"`
+		Slug          string `json:"slug"`
+		Type          string `json:"type"`
+		Overages      bool   `json:"overages"`
+		BlockedReads  bool   `json:"blocked_reads"`
+		BlockedWrites bool   `json:"blocked_writes"`
+		PlanID        string `json:"plan_id"`
+		PlanTimeline  string `json:"plan_timeline"`
+		Platform      string `json:"platform"`
+	}
+
+	// OrganizationUpdate is an update of the settings of an organization.
+	//
+	// Nil fields are left unchanged.
+	OrganizationUpdate struct {
+		Overages *bool `json:"overages,omitempty"`
+	}
+
+	// Member is a member of an organization.
+	Member struct {
+		Username string `json:"username"`
+		Role     string `json:"role"`
+		Email    string `json:"email"`
+	}
+
+	// MemberConfig is a struct configures the addition of a member to an
+	// organization.
+	MemberConfig struct {
+		Username string `json:"username" validate:"required"`
+		Role     string `json:"role" validate:"required,oneof=admin member viewer"`
+	}
+
+	// Invite is an invitation to join an organization.
+	Invite struct {
+		ID             int       `json:"ID"`
+		Email          string    `json:"Email"`
+		Role           string    `json:"Role"`
+		OrganizationID int       `json:"OrganizationID"`
+		Token          string    `json:"Token"`
+		Accepted       bool      `json:"Accepted"`
+		CreatedAt      time.Time `json:"CreatedAt"`
+		UpdatedAt      time.Time `json:"UpdatedAt"`
+	}
+
+	// InviteConfig is a struct configures the invitation of a user to an
+	// organization.
+	InviteConfig struct {
+		Email string `json:"email" validate:"required,email"`
+		Role  string `json:"role" validate:"required,oneof=admin member viewer"`
+	}
+)
+
+// ListOrganizations lists the organizations the API token can access.
+func (c *Client) ListOrganizations(ctx context.Context) ([]Organization, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		fmt.Sprintf("%s/organizations", c.baseURL),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp []Organization
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	return resp, nil
+}
+
+// GetOrganization returns the organization of the Client.
+func (c *Client) GetOrganization(ctx context.Context) (*Organization, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		fmt.Sprintf("%s/organizations/%s", c.baseURL, c.orgName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Organization Organization `json:"organization"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get organization %s: %w",
+			c.orgName, err,
+		)
+	}
+	return &resp.Organization, nil
+}
+
+// UpdateOrganization updates the settings of the organization of the Client.
+func (c *Client) UpdateOrganization(
+	ctx context.Context,
+	update OrganizationUpdate,
+) (*Organization, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodPatch,
+		fmt.Sprintf("%s/organizations/%s", c.baseURL, c.orgName),
+		builders.WithBody(update),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Organization Organization `json:"organization"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to update organization %s: %w",
+			c.orgName, err,
+		)
+	}
+	return &resp.Organization, nil
+}
+
+// ListMembers lists the members of the organization of the Client.
+func (c *Client) ListMembers(ctx context.Context) ([]Member, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		fmt.Sprintf("%s/organizations/%s/members", c.baseURL, c.orgName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Members []Member `json:"members"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+	return resp.Members, nil
+}
+
+// AddMember adds an existing turso user to the organization of the Client
+// with the given role.
+func (c *Client) AddMember(ctx context.Context, config MemberConfig) error {
+	err := c.validate(config)
+	if err != nil {
+		return err
+	}
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodPost,
+		fmt.Sprintf("%s/organizations/%s/members", c.baseURL, c.orgName),
+		builders.WithBody(config),
+	)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Member string `json:"member"`
+		Role   string `json:"role"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return fmt.Errorf("failed to add member %s: %w", config.Username, err)
+	}
+	return nil
+}
+
+// RemoveMember removes the user with the given username from the
+// organization of the Client.
+func (c *Client) RemoveMember(ctx context.Context, username string) error {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodDelete,
+		fmt.Sprintf(
+			"%s/organizations/%s/members/%s",
+			c.baseURL, c.orgName, username,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Member string `json:"member"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return fmt.Errorf("failed to remove member %s: %w", username, err)
+	}
+	return nil
+}
+
+// ListInvites lists the pending invites of the organization of the Client.
+func (c *Client) ListInvites(ctx context.Context) ([]Invite, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		fmt.Sprintf("%s/organizations/%s/invites", c.baseURL, c.orgName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Invites []Invite `json:"invites"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invites: %w", err)
+	}
+	return resp.Invites, nil
+}
+
+// CreateInvite invites the user with the given email to the organization of
+// the Client with the given role.
+func (c *Client) CreateInvite(
+	ctx context.Context,
+	config InviteConfig,
+) (*Invite, error) {
+	err := c.validate(config)
+	if err != nil {
+		return nil, err
+	}
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodPost,
+		fmt.Sprintf("%s/organizations/%s/invites", c.baseURL, c.orgName),
+		builders.WithBody(config),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Invited Invite `json:"invited"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invite %s: %w", config.Email, err)
+	}
+	return &resp.Invited, nil
+}
+
+// RevokeInvite revokes the pending invite of the user with the given email.
+func (c *Client) RevokeInvite(ctx context.Context, email string) error {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodDelete,
+		fmt.Sprintf(
+			"%s/organizations/%s/invites/%s",
+			c.baseURL, c.orgName, email,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	err = c.sendRequest(req, nil)
+	if err != nil {
+		return fmt.Errorf("failed to revoke invite of %s: %w", email, err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package dbpu
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListMembers(t *testing.T) {
+	srv, requests, _ := recordingServer(t,
+		`{"members":[{"username":"alice","role":"owner","email":"alice@example.com"}]}`)
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+
+	members, err := c.ListMembers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Member{
+		{Username: "alice", Role: "owner", Email: "alice@example.com"},
+	}, members)
+	assert.Equal(t, []string{"GET /organizations/org/members"}, *requests)
+}
+
+func TestAddMember(t *testing.T) {
+	srv, requests, bodies := recordingServer(t, `{"member":"bob","role":"viewer"}`)
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	err := c.AddMember(ctx, MemberConfig{Username: "bob", Role: "owner"})
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 1)
+	assert.Equal(t, "role", verr.Fields[0].Field)
+	assert.Equal(t, "oneof", verr.Fields[0].Rule)
+	assert.Empty(t, *requests)
+
+	require.NoError(t, c.AddMember(ctx, MemberConfig{Username: "bob", Role: "viewer"}))
+	assert.Equal(t, []string{"POST /organizations/org/members"}, *requests)
+	assert.JSONEq(t, `{"username":"bob","role":"viewer"}`, (*bodies)[0])
+}
@@ -0,0 +1,167 @@
+package dbpu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type (
+	provisionerOpt func(*Provisioner)
+
+	// Provisioner provisions a database per user.
+	//
+	// Each user is given its own database in the configured group and
+	// location along with a token scoped to that database.
+	Provisioner struct {
+		client        *Client
+		group         string
+		location      Location
+		naming        NameStrategy
+		tokenTTL      time.Duration
+		authorization string
+		now           func() time.Time
+	}
+
+	// TenantDatabase is a database provisioned for a user.
+	TenantDatabase struct {
+		// UserID is the ID of the user owning the database.
+		UserID string
+		// Name is the name of the database.
+		Name string
+		// URL is the libsql URL of the database.
+		URL string
+		// Database is the database as returned by the turso API.
+		Database Database
+		// Token is a token scoped to the database.
+		Token string
+		// ExpiresAt is the time the token expires, zero if it never expires.
+		ExpiresAt time.Time
+		// Created reports whether the database was created by the call.
+		Created bool
+	}
+)
+
+// WithTenantNaming sets the strategy mapping a user ID to a database name.
+//
+// By default the database is named after a 128-bit hash of the user ID
+// (HashStrategy with a Length of 32).
+//
+// The strategy must not map distinct users to the same name: Provision
+// returns an existing database as is, so a colliding user would be handed
+// the database of another user along with a token for it. Strategies such as
+// SlugStrategy, or HashStrategy with a short Length, can collide; check them
+// against the known users with CheckCollisions.
+func WithTenantNaming(naming NameStrategy) func(*Provisioner) {
+	return func(p *Provisioner) { p.naming = naming }
+}
+
+// WithTokenTTL sets the lifetime of the tokens minted by the Provisioner.
+//
+// By default tokens never expire.
+func WithTokenTTL(ttl time.Duration) func(*Provisioner) {
+	return func(p *Provisioner) { p.tokenTTL = ttl }
+}
+
+// WithTokenAuthorization sets the authorization level of the tokens minted by
+// the Provisioner (full-access or read-only).
+func WithTokenAuthorization(authorization string) func(*Provisioner) {
+	return func(p *Provisioner) { p.authorization = authorization }
+}
+
+// NewProvisioner returns a new Provisioner creating databases in the given
+// group and location.
+func NewProvisioner(
+	client *Client,
+	group string,
+	location Location,
+	opts ...provisionerOpt,
+) *Provisioner {
+	p := &Provisioner{
+		client:   client,
+		group:    group,
+		location: location,
+		naming:   HashStrategy{Length: 32},
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Provision returns the database of the given user along with a fresh token
+// for it.
+//
+// Provision is idempotent: if the database of the user already exists it is
+// returned instead of being created.
+func (p *Provisioner) Provision(
+	ctx context.Context,
+	userID string,
+) (*TenantDatabase, error) {
+	name, err := p.naming.Name(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to name database of %s: %w", userID, err)
+	}
+	db, created, err := p.database(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	opts := []newDbTokenOpt{}
+	if p.authorization != "" {
+		opts = append(opts, WithAuthorization(p.authorization))
+	}
+	var expiresAt time.Time
+	if p.tokenTTL > 0 {
+		opts = append(opts, WithTokenExpiry(TokenExpiry(p.tokenTTL)))
+		expiresAt = p.now().Add(p.tokenTTL)
+	}
+	token, err := p.client.CreateDatabaseToken(ctx, name, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token for %s: %w", name, err)
+	}
+	return &TenantDatabase{
+		UserID:    userID,
+		Name:      name,
+		URL:       libsqlURL(db.Hostname),
+		Database:  *db,
+		Token:     token,
+		ExpiresAt: expiresAt,
+		Created:   created,
+	}, nil
+}
+
+// database gets the database with the given name, creating it if it does not
+// exist yet.
+func (p *Provisioner) database(
+	ctx context.Context,
+	name string,
+) (*Database, bool, error) {
+	db, err := p.client.GetDatabase(ctx, name)
+	if err == nil {
+		return db, false, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, false, err
+	}
+	db, err = p.client.Create(ctx, Config{
+		Name:     name,
+		Location: p.location,
+		Group:    p.group,
+	})
+	if err == nil {
+		return db, true, nil
+	}
+	// Another caller created the database in the meantime.
+	if errors.Is(err, ErrAlreadyExists) {
+		db, err = p.client.GetDatabase(ctx, name)
+		return db, false, err
+	}
+	return nil, false, err
+}
+
+// libsqlURL returns the libsql URL of the database with the given hostname.
+func libsqlURL(hostname string) string {
+	return "libsql://" + hostname
+}
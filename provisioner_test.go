@@ -0,0 +1,81 @@
+package dbpu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvisioner_Provision(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		existing = map[string]bool{"alice": true}
+		requests []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		path := strings.TrimPrefix(r.URL.Path, "/organizations/org/databases")
+		requests = append(requests, r.Method+" "+path)
+		switch {
+		case strings.HasSuffix(path, "/auth/tokens"):
+			_, _ = w.Write([]byte(`{"jwt":"token"}`))
+		case r.Method == http.MethodGet:
+			name := strings.TrimPrefix(path, "/")
+			if !existing[name] {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":"database not found"}`))
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"database":{"Name":%q,"Hostname":"%s-org.turso.io"}}`, name, name)
+		case r.Method == http.MethodPost:
+			var config Config
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&config))
+			assert.Equal(t, "tenants", config.Group)
+			if config.Name == "carol" {
+				// Another caller created the database in the meantime.
+				existing[config.Name] = true
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"error":"database already exists"}`))
+				return
+			}
+			existing[config.Name] = true
+			_, _ = fmt.Fprintf(w, `{"database":{"Name":%q,"Hostname":"%s-org.turso.io"}}`, config.Name, config.Name)
+		}
+	}))
+	defer srv.Close()
+
+	p := NewProvisioner(
+		NewClient("token", "org", WithBaseURL(srv.URL)),
+		"tenants", "ams",
+		WithTenantNaming(SlugStrategy{}),
+	)
+	ctx := context.Background()
+	tests := []struct {
+		user     string
+		created  bool
+		requests []string
+	}{
+		{"alice", false, []string{"GET /alice", "POST /alice/auth/tokens"}},
+		{"bob", true, []string{"GET /bob", "POST ", "POST /bob/auth/tokens"}},
+		{"carol", false, []string{"GET /carol", "POST ", "GET /carol", "POST /carol/auth/tokens"}},
+	}
+	for _, tt := range tests {
+		requests = nil
+		tenant, err := p.Provision(ctx, tt.user)
+		require.NoError(t, err, tt.user)
+		assert.Equal(t, tt.created, tenant.Created, tt.user)
+		assert.Equal(t, tt.user, tenant.Name, tt.user)
+		assert.Equal(t, "libsql://"+tt.user+"-org.turso.io", tenant.URL, tt.user)
+		assert.Equal(t, "token", tenant.Token, tt.user)
+		assert.Equal(t, tt.requests, requests, tt.user)
+	}
+}
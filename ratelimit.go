@@ -0,0 +1,197 @@
+package dbpu
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type (
+	// RateLimiter is a token bucket rate limiter for requests made to the
+	// turso API.
+	//
+	// Read requests (GET, HEAD and OPTIONS) and mutating requests draw from
+	// separate budgets. The budgets are adjusted from the rate limit headers
+	// of the responses. A RateLimiter is safe for concurrent use and can be
+	// shared by several Clients to keep a whole process under quota.
+	RateLimiter struct {
+		mu    sync.Mutex
+		read  bucket
+		write bucket
+		now   func() time.Time
+	}
+
+	// bucket is a token bucket.
+	bucket struct {
+		rate     float64 // Tokens added per second.
+		burst    float64 // Maximum number of tokens.
+		maxRate  float64 // Configured rate, the ceiling of rate.
+		maxBurst float64 // Configured burst, the ceiling of burst.
+		tokens   float64
+		last     time.Time
+		blocked  time.Time // No tokens are handed out before blocked.
+	}
+)
+
+// NewRateLimiter returns a new RateLimiter allowing the given number of read
+// and mutating requests per second, each with the given burst.
+func NewRateLimiter(readsPerSecond, writesPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	now := time.Now()
+	return &RateLimiter{
+		read:  newBucket(readsPerSecond, float64(burst), now),
+		write: newBucket(writesPerSecond, float64(burst), now),
+		now:   time.Now,
+	}
+}
+
+// newBucket returns a full bucket with the given rate and burst.
+func newBucket(rate, burst float64, now time.Time) bucket {
+	return bucket{
+		rate:     rate,
+		burst:    burst,
+		maxRate:  rate,
+		maxBurst: burst,
+		tokens:   burst,
+		last:     now,
+	}
+}
+
+// WithRateLimiter sets the rate limiter of the Client.
+//
+// The same RateLimiter can be given to several Clients.
+func WithRateLimiter(limiter *RateLimiter) func(*Client) {
+	return func(c *Client) { c.limiter = limiter }
+}
+
+// Wait blocks until the request is allowed by the budget matching its method
+// or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context, method string) error {
+	for {
+		l.mu.Lock()
+		delay := l.bucket(method).take(l.now())
+		l.mu.Unlock()
+		if delay <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Observe adjusts the budget matching the method of the request from the
+// rate limit headers of the response.
+//
+// The budget is adjusted on the fly, never beyond the rate and burst it was
+// configured with:
+//   - the X-RateLimit-Limit header caps the burst;
+//   - the X-RateLimit-Remaining header caps the available tokens;
+//   - the rate is lowered to spread the remaining requests until the
+//     X-RateLimit-Reset header, given in seconds or as a Unix timestamp, and
+//     a depleted budget is blocked until then.
+//
+// A 429 Too Many Requests response blocks the budget until its Retry-After
+// header.
+func (l *RateLimiter) Observe(res *http.Response) {
+	if res == nil || res.Request == nil {
+		return
+	}
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := l.bucket(res.Request.Method)
+	b.refill(now)
+	if limit, err := strconv.ParseFloat(
+		res.Header.Get("X-RateLimit-Limit"), 64,
+	); err == nil && limit >= 1 {
+		b.burst = min(limit, b.maxBurst)
+		b.tokens = min(b.tokens, b.burst)
+	}
+	remaining, err := strconv.ParseFloat(
+		res.Header.Get("X-RateLimit-Remaining"), 64,
+	)
+	hasRemaining := err == nil && remaining >= 0
+	if hasRemaining && remaining < b.tokens {
+		b.tokens = remaining
+	}
+	if reset, ok := rateLimitReset(res.Header.Get("X-RateLimit-Reset"), now); ok {
+		if hasRemaining && remaining >= 1 && reset.After(now) {
+			b.rate = min(remaining/reset.Sub(now).Seconds(), b.maxRate)
+		}
+		if b.tokens < 1 {
+			b.block(reset)
+		}
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		b.tokens = 0
+		if delay, ok := retryAfter(res); ok {
+			b.block(now.Add(delay))
+		}
+	}
+}
+
+// bucket returns the bucket of requests made with the given method.
+func (l *RateLimiter) bucket(method string) *bucket {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return &l.read
+	}
+	return &l.write
+}
+
+// take takes a token from the bucket, returning how long to wait before
+// trying again if none is available.
+func (b *bucket) take(now time.Time) time.Duration {
+	if now.Before(b.blocked) {
+		return b.blocked.Sub(now)
+	}
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	if b.rate <= 0 {
+		return time.Second
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *bucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+}
+
+func (b *bucket) block(until time.Time) {
+	if until.After(b.blocked) {
+		b.blocked = until
+		b.last = until
+	}
+}
+
+// rateLimitReset parses a X-RateLimit-Reset header given either in seconds
+// from now or as a Unix timestamp.
+func rateLimitReset(header string, now time.Time) (time.Time, bool) {
+	reset, err := strconv.ParseInt(header, 10, 64)
+	if err != nil || reset < 0 {
+		return time.Time{}, false
+	}
+	// Values larger than a year of seconds can only be timestamps.
+	if reset > 365*24*60*60 {
+		return time.Unix(reset, 0), true
+	}
+	return now.Add(time.Duration(reset) * time.Second), true
+}
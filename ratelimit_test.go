@@ -0,0 +1,122 @@
+package dbpu
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRateLimiter returns a RateLimiter driven by the returned clock.
+func newTestRateLimiter(reads, writes float64, burst int) (*RateLimiter, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	l := NewRateLimiter(reads, writes, burst)
+	l.now = func() time.Time { return now }
+	l.read.last, l.write.last = now, now
+	return l, &now
+}
+
+func rateLimitResponse(method string, status int, headers map[string]string) *http.Response {
+	res := &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Request:    &http.Request{Method: method},
+	}
+	for k, v := range headers {
+		res.Header.Set(k, v)
+	}
+	return res
+}
+
+func TestBucket_Take(t *testing.T) {
+	l, now := newTestRateLimiter(2, 2, 2)
+	b := l.bucket(http.MethodGet)
+	assert.Zero(t, b.take(*now))
+	assert.Zero(t, b.take(*now))
+	assert.Equal(t, 500*time.Millisecond, b.take(*now))
+
+	*now = now.Add(500 * time.Millisecond)
+	assert.Zero(t, b.take(*now))
+
+	// Refills never exceed the burst.
+	*now = now.Add(time.Hour)
+	assert.Zero(t, b.take(*now))
+	assert.Zero(t, b.take(*now))
+	assert.Positive(t, b.take(*now))
+}
+
+func TestBucket_Block(t *testing.T) {
+	l, now := newTestRateLimiter(10, 10, 5)
+	b := l.bucket(http.MethodPost)
+	b.block(now.Add(3 * time.Second))
+	assert.Equal(t, 3*time.Second, b.take(*now))
+
+	// An earlier block does not shorten the current one.
+	b.block(now.Add(time.Second))
+	assert.Equal(t, 3*time.Second, b.take(*now))
+
+	*now = now.Add(3 * time.Second)
+	assert.Zero(t, b.take(*now))
+}
+
+func TestRateLimiter_SeparateBudgets(t *testing.T) {
+	l, now := newTestRateLimiter(1, 1, 1)
+	assert.Zero(t, l.bucket(http.MethodPost).take(*now))
+	assert.Positive(t, l.bucket(http.MethodDelete).take(*now))
+	assert.Zero(t, l.bucket(http.MethodGet).take(*now))
+	assert.Positive(t, l.bucket(http.MethodHead).take(*now))
+
+	l.Observe(rateLimitResponse(http.MethodPatch, http.StatusTooManyRequests,
+		map[string]string{"Retry-After": "7"}))
+	assert.Equal(t, 7*time.Second, l.bucket(http.MethodPost).take(*now))
+	assert.Equal(t, time.Second, l.bucket(http.MethodGet).take(*now))
+}
+
+func TestRateLimiter_Observe(t *testing.T) {
+	l, now := newTestRateLimiter(100, 100, 50)
+	l.Observe(rateLimitResponse(http.MethodGet, http.StatusOK, map[string]string{
+		"X-RateLimit-Limit":     "20",
+		"X-RateLimit-Remaining": "10",
+		"X-RateLimit-Reset":     "5",
+	}))
+	b := l.bucket(http.MethodGet)
+	assert.Equal(t, 20.0, b.burst)
+	assert.Equal(t, 10.0, b.tokens)
+	assert.Equal(t, 2.0, b.rate)
+	assert.Equal(t, 100.0, l.bucket(http.MethodPost).rate)
+
+	// The configured rate and burst are ceilings.
+	l.Observe(rateLimitResponse(http.MethodGet, http.StatusOK, map[string]string{
+		"X-RateLimit-Limit":     "1000",
+		"X-RateLimit-Remaining": "1000",
+		"X-RateLimit-Reset":     "1",
+	}))
+	assert.Equal(t, 50.0, b.burst)
+	assert.Equal(t, 100.0, b.rate)
+
+	// A depleted budget is blocked until the reset.
+	reset := now.Add(30 * time.Second)
+	l.Observe(rateLimitResponse(http.MethodGet, http.StatusOK, map[string]string{
+		"X-RateLimit-Remaining": "0",
+		"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+	}))
+	assert.Equal(t, 30*time.Second, b.take(*now))
+}
+
+func TestRateLimitReset(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	reset, ok := rateLimitReset("30", now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(30*time.Second), reset)
+
+	reset, ok = rateLimitReset("1700000060", now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(1700000060, 0), reset)
+
+	for _, header := range []string{"", "-1", "soon"} {
+		_, ok = rateLimitReset(header, now)
+		assert.False(t, ok, header)
+	}
+}
@@ -0,0 +1,212 @@
+package dbpu
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type (
+	// RetryPolicy configures how a Client retries failed requests.
+	//
+	// Only idempotent requests (GET, HEAD, OPTIONS, PUT and DELETE) and
+	// requests whose context was marked with RetrySafe are retried. Network
+	// errors, 429 Too Many Requests and 5xx responses other than 501 Not
+	// Implemented are considered transient.
+	RetryPolicy struct {
+		// MaxAttempts is the maximum number of attempts of a request,
+		// including the first one.
+		MaxAttempts int
+		// BaseDelay is the delay before the first retry. It doubles on every
+		// following retry.
+		BaseDelay time.Duration
+		// MaxDelay caps the exponential delay between two attempts.
+		MaxDelay time.Duration
+		// OnAttempt, if set, is called after every attempt of a request.
+		OnAttempt func(RetryAttempt)
+	}
+
+	// RetryAttempt describes an attempt of a request made by a Client.
+	RetryAttempt struct {
+		// Request is the attempted request.
+		Request *http.Request
+		// Attempt is the number of the attempt, starting at 1.
+		Attempt int
+		// StatusCode is the status code of the response, zero if the request
+		// failed before a response was received.
+		StatusCode int
+		// Err is the error returned by the HTTP client, if any.
+		Err error
+		// Delay is the delay before the next attempt, zero if the request is
+		// not retried.
+		Delay time.Duration
+	}
+
+	retrySafeKey struct{}
+)
+
+// DefaultRetryPolicy returns a RetryPolicy making up to 4 attempts with a
+// delay starting at 250ms and capped at 10s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   250 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+	}
+}
+
+// WithRetryPolicy sets the retry policy of the Client.
+//
+// By default requests are not retried.
+func WithRetryPolicy(policy RetryPolicy) func(*Client) {
+	return func(c *Client) { c.retry = policy }
+}
+
+// RetrySafe marks the requests made with the returned context as safe to
+// retry even though their method is not idempotent.
+func RetrySafe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retrySafeKey{}, true)
+}
+
+// do sends the request within the budget of the rate limiter of the Client,
+// retrying it according to the retry policy of the Client.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			err := c.limiter.Wait(req.Context(), req.Method)
+			if err != nil {
+				return nil, err
+			}
+		}
+		res, err := c.client.Do(req)
+		if c.limiter != nil {
+			c.limiter.Observe(res)
+		}
+		delay, retry := c.retry.next(req, attempt, res, err)
+		if c.retry.OnAttempt != nil {
+			a := RetryAttempt{Request: req, Attempt: attempt, Err: err}
+			if res != nil {
+				a.StatusCode = res.StatusCode
+			}
+			if retry {
+				a.Delay = delay
+			}
+			c.retry.OnAttempt(a)
+		}
+		if !retry {
+			return res, err
+		}
+		if res != nil {
+			res.Body.Close()
+		}
+		req, err = rewind(req)
+		if err != nil {
+			return nil, err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// next returns the delay before the next attempt of the request and whether
+// it should be retried at all.
+func (p RetryPolicy) next(
+	req *http.Request,
+	attempt int,
+	res *http.Response,
+	err error,
+) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || !retryable(req) {
+		return 0, false
+	}
+	if err != nil {
+		if req.Context().Err() != nil || errors.Is(err, context.Canceled) {
+			return 0, false
+		}
+		return p.backoff(attempt), true
+	}
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests,
+		res.StatusCode >= http.StatusInternalServerError &&
+			res.StatusCode != http.StatusNotImplemented:
+	default:
+		return 0, false
+	}
+	if delay, ok := retryAfter(res); ok {
+		return delay, true
+	}
+	return p.backoff(attempt), true
+}
+
+// backoff returns the exponential delay after the given attempt with equal
+// jitter applied.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// retryable reports whether the request may be sent more than once.
+func retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+	safe, _ := req.Context().Value(retrySafeKey{}).(bool)
+	return safe
+}
+
+// rewind returns a copy of the request with a fresh body.
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+// retryAfter parses the Retry-After header of the response, given either in
+// seconds or as an HTTP date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	header := res.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		delay := time.Until(at)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
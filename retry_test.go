@@ -0,0 +1,135 @@
+package dbpu
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryPolicy(attempts *[]RetryAttempt) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+		OnAttempt: func(a RetryAttempt) {
+			*attempts = append(*attempts, a)
+		},
+	}
+}
+
+func TestRetry_TransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"database":{"Name":"db"}}`))
+	}))
+	defer srv.Close()
+
+	var attempts []RetryAttempt
+	c := NewClient("token", "org",
+		WithBaseURL(srv.URL),
+		WithRetryPolicy(testRetryPolicy(&attempts)),
+	)
+	db, err := c.GetDatabase(context.Background(), "db")
+	require.NoError(t, err)
+	assert.Equal(t, "db", db.Name)
+	assert.Equal(t, int32(3), calls.Load())
+	require.Len(t, attempts, 3)
+	assert.Equal(t, http.StatusServiceUnavailable, attempts[0].StatusCode)
+	assert.Positive(t, attempts[0].Delay)
+	assert.Equal(t, http.StatusOK, attempts[2].StatusCode)
+	assert.Zero(t, attempts[2].Delay)
+}
+
+func TestRetry_GivesUp(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	var attempts []RetryAttempt
+	c := NewClient("token", "org",
+		WithBaseURL(srv.URL),
+		WithRetryPolicy(testRetryPolicy(&attempts)),
+	)
+	_, err := c.ListDatabases(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Len(t, attempts, 3)
+}
+
+func TestRetry_RetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"databases":[]}`))
+	}))
+	defer srv.Close()
+
+	var attempts []RetryAttempt
+	policy := testRetryPolicy(&attempts)
+	policy.BaseDelay = time.Hour
+	policy.MaxDelay = time.Hour
+	c := NewClient("token", "org",
+		WithBaseURL(srv.URL),
+		WithRetryPolicy(policy),
+	)
+	_, err := c.ListDatabases(context.Background())
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+	assert.Equal(t, http.StatusTooManyRequests, attempts[0].StatusCode)
+	assert.Zero(t, attempts[0].Delay)
+}
+
+func TestRetry_OnlySafeRequests(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	var attempts []RetryAttempt
+	c := NewClient("token", "org",
+		WithBaseURL(srv.URL),
+		WithRetryPolicy(testRetryPolicy(&attempts)),
+	)
+	config := Config{Name: "db", Location: "ams", Group: "default"}
+	_, err := c.Create(context.Background(), config)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+
+	calls.Store(0)
+	_, err = c.Create(RetrySafe(context.Background()), config)
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetry_Disabled(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+	_, err := c.ListDatabases(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,234 @@
+package dbpu
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	tokenCacheOpt func(*TokenCache)
+
+	// TokenStore stores the tokens of a TokenCache.
+	//
+	// Implementations must be safe for concurrent use.
+	TokenStore interface {
+		// Get returns the token stored under the key, if any.
+		Get(ctx context.Context, key string) (token string, ok bool, err error)
+		// Set stores the token under the key until it expires, a zero
+		// expiresAt meaning it never does.
+		Set(ctx context.Context, key, token string, expiresAt time.Time) error
+		// Delete removes the token stored under the key.
+		Delete(ctx context.Context, key string) error
+	}
+
+	// MemoryTokenStore is an in-memory TokenStore.
+	MemoryTokenStore struct {
+		mu     sync.RWMutex
+		tokens map[string]string
+	}
+
+	// TokenCache caches database tokens and refreshes them before they
+	// expire.
+	//
+	// Tokens are keyed by organization, database name and authorization
+	// level. Concurrent refreshes of the same token are collapsed into a
+	// single call to the turso API. A TokenCache is safe for concurrent use.
+	TokenCache struct {
+		client        *Client
+		store         TokenStore
+		expiry        TokenExpiry
+		refreshBefore time.Duration
+		mintTimeout   time.Duration
+		now           func() time.Time
+
+		mu      sync.Mutex
+		pending map[string]*tokenCall
+	}
+
+	// tokenCall is a pending refresh of a token.
+	tokenCall struct {
+		done  chan struct{}
+		token string
+		err   error
+	}
+)
+
+// WithTokenStore sets the store of the TokenCache, defaulting to a
+// MemoryTokenStore.
+func WithTokenStore(store TokenStore) func(*TokenCache) {
+	return func(c *TokenCache) { c.store = store }
+}
+
+// WithCachedTokenExpiry sets the expiration of the tokens minted by the
+// TokenCache, defaulting to a day.
+func WithCachedTokenExpiry(expiry TokenExpiry) func(*TokenCache) {
+	return func(c *TokenCache) { c.expiry = expiry }
+}
+
+// WithRefreshBefore sets how long before their expiration tokens are
+// refreshed, defaulting to 5 minutes.
+func WithRefreshBefore(d time.Duration) func(*TokenCache) {
+	return func(c *TokenCache) { c.refreshBefore = d }
+}
+
+// NewTokenCache returns a new TokenCache minting tokens with the given
+// client.
+func NewTokenCache(client *Client, opts ...tokenCacheOpt) *TokenCache {
+	c := &TokenCache{
+		client:        client,
+		store:         NewMemoryTokenStore(),
+		expiry:        TokenExpiry(24 * time.Hour),
+		refreshBefore: 5 * time.Minute,
+		mintTimeout:   time.Minute,
+		now:           time.Now,
+		pending:       map[string]*tokenCall{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Token returns a token for the database with the given name and
+// authorization level (full-access or read-only), minting a new one if none
+// is cached or the cached one is about to expire.
+func (c *TokenCache) Token(
+	ctx context.Context,
+	dbName, authorization string,
+) (string, error) {
+	key := c.key(dbName, authorization)
+	token, ok, err := c.store.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if ok && c.fresh(token) {
+		return token, nil
+	}
+	return c.refresh(ctx, key, dbName, authorization)
+}
+
+// Invalidate removes the cached token of the database with the given name
+// and authorization level, so that the next call to Token mints a new one.
+func (c *TokenCache) Invalidate(
+	ctx context.Context,
+	dbName, authorization string,
+) error {
+	return c.store.Delete(ctx, c.key(dbName, authorization))
+}
+
+// fresh reports whether the token is not about to expire.
+func (c *TokenCache) fresh(token string) bool {
+	claims, err := ParseDatabaseToken(token)
+	if err != nil {
+		return false
+	}
+	return !claims.ExpiredAt(c.now().Add(c.refreshBefore))
+}
+
+// refresh mints a new token for the key, joining the pending refresh of the
+// key if there is one.
+//
+// The token is minted in the background, detached from the cancellation of
+// the caller that started it, so that a cancelled caller does not fail the
+// others waiting on the same key.
+func (c *TokenCache) refresh(
+	ctx context.Context,
+	key, dbName, authorization string,
+) (string, error) {
+	c.mu.Lock()
+	call, ok := c.pending[key]
+	if !ok {
+		call = &tokenCall{done: make(chan struct{})}
+		c.pending[key] = call
+		go c.run(context.WithoutCancel(ctx), call, key, dbName, authorization)
+	}
+	c.mu.Unlock()
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// run mints the token of the pending call and releases its waiters.
+func (c *TokenCache) run(
+	ctx context.Context,
+	call *tokenCall,
+	key, dbName, authorization string,
+) {
+	ctx, cancel := context.WithTimeout(ctx, c.mintTimeout)
+	defer cancel()
+	call.token, call.err = c.mint(ctx, key, dbName, authorization)
+
+	c.mu.Lock()
+	delete(c.pending, key)
+	c.mu.Unlock()
+	close(call.done)
+}
+
+func (c *TokenCache) mint(
+	ctx context.Context,
+	key, dbName, authorization string,
+) (string, error) {
+	opts := []newDbTokenOpt{WithTokenExpiry(c.expiry)}
+	if authorization != "" {
+		opts = append(opts, WithAuthorization(authorization))
+	}
+	token, err := c.client.CreateDatabaseToken(ctx, dbName, opts...)
+	if err != nil {
+		return "", err
+	}
+	var expiresAt time.Time
+	if claims, err := ParseDatabaseToken(token); err == nil {
+		expiresAt = claims.ExpiresAt
+	} else if !c.expiry.Never() {
+		expiresAt = c.now().Add(c.expiry.Duration())
+	}
+	err = c.store.Set(ctx, key, token, expiresAt)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// key returns the key of the token of the database with the given name and
+// authorization level.
+//
+// Keys include the organization of the client so that caches of different
+// organizations can share a TokenStore.
+func (c *TokenCache) key(dbName, authorization string) string {
+	return c.client.OrgName() + "/" + dbName + "/" + authorization
+}
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: map[string]string{}}
+}
+
+// Get returns the token stored under the key, if any.
+func (s *MemoryTokenStore) Get(_ context.Context, key string) (string, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	token, ok := s.tokens[key]
+	return token, ok, nil
+}
+
+// Set stores the token under the key.
+//
+// Expired tokens are replaced by the TokenCache, so expiresAt is unused.
+func (s *MemoryTokenStore) Set(_ context.Context, key, token string, _ time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[key] = token
+	return nil
+}
+
+// Delete removes the token stored under the key.
+func (s *MemoryTokenStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, key)
+	return nil
+}
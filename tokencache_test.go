@@ -0,0 +1,150 @@
+package dbpu
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenServer mints signed database tokens expiring in an hour, holding each
+// response until release is closed if it is not nil.
+func tokenServer(
+	t *testing.T,
+	calls *atomic.Int32,
+	release chan struct{},
+) *httptest.Server {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		if release != nil {
+			<-release
+		}
+		token := signTestToken(t, private, fmt.Sprintf(
+			`{"id":"token-%d","exp":%d}`,
+			n, time.Now().Add(time.Hour).Unix(),
+		))
+		_, _ = fmt.Fprintf(w, `{"jwt":%q}`, token)
+	}))
+}
+
+func TestTokenCache_SingleFlight(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	srv := tokenServer(t, &calls, release)
+	defer srv.Close()
+	cache := NewTokenCache(NewClient("token", "org", WithBaseURL(srv.URL)))
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 20)
+	for i := range tokens {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := cache.Token(context.Background(), "db", "full-access")
+			assert.NoError(t, err)
+			tokens[i] = token
+		}()
+	}
+	require.Eventually(t, func() bool { return calls.Load() == 1 },
+		time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, token := range tokens {
+		assert.Equal(t, tokens[0], token)
+	}
+}
+
+func TestTokenCache_RefreshBefore(t *testing.T) {
+	var calls atomic.Int32
+	srv := tokenServer(t, &calls, nil)
+	defer srv.Close()
+	cache := NewTokenCache(
+		NewClient("token", "org", WithBaseURL(srv.URL)),
+		WithRefreshBefore(5*time.Minute),
+	)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	first, err := cache.Token(ctx, "db", "")
+	require.NoError(t, err)
+	cached, err := cache.Token(ctx, "db", "")
+	require.NoError(t, err)
+	assert.Equal(t, first, cached)
+	assert.Equal(t, int32(1), calls.Load())
+
+	claims, err := ParseDatabaseToken(first)
+	require.NoError(t, err)
+	now = claims.ExpiresAt.Add(-4 * time.Minute)
+	refreshed, err := cache.Token(ctx, "db", "")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, refreshed)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestTokenCache_CallerIsolation(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	srv := tokenServer(t, &calls, release)
+	defer srv.Close()
+	cache := NewTokenCache(NewClient("token", "org", WithBaseURL(srv.URL)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := cache.Token(ctx, "db", "")
+		first <- err
+	}()
+	require.Eventually(t, func() bool { return calls.Load() == 1 },
+		time.Second, time.Millisecond)
+
+	second := make(chan error, 1)
+	go func() {
+		token, err := cache.Token(context.Background(), "db", "")
+		if err == nil && token == "" {
+			err = fmt.Errorf("empty token")
+		}
+		second <- err
+	}()
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+
+	close(release)
+	assert.NoError(t, <-second)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestTokenCache_SharedStoreAcrossOrgs(t *testing.T) {
+	var calls atomic.Int32
+	srv := tokenServer(t, &calls, nil)
+	defer srv.Close()
+	store := NewMemoryTokenStore()
+	client := NewClient("token", "org-a", WithBaseURL(srv.URL))
+	a := NewTokenCache(client, WithTokenStore(store))
+	b := NewTokenCache(client.Org("org-b"), WithTokenStore(store))
+	ctx := context.Background()
+
+	tokenA, err := a.Token(ctx, "db", "")
+	require.NoError(t, err)
+	tokenB, err := b.Token(ctx, "db", "")
+	require.NoError(t, err)
+	assert.NotEqual(t, tokenA, tokenB)
+	assert.Equal(t, int32(2), calls.Load())
+
+	cached, err := a.Token(ctx, "db", "")
+	require.NoError(t, err)
+	assert.Equal(t, tokenA, cached)
+}
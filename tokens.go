@@ -0,0 +1,312 @@
+package dbpu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/conneroisu/dbpu/internal/builders"
+)
+
+type (
+	// Permissions are the fine-grained permission claims of a token.
+	//
+	// Build them with NewPermissions and pass them to WithPermissions.
+	Permissions struct {
+		// ReadAttach allows the token to attach the listed databases
+		// read-only.
+		ReadAttach *ReadAttach `json:"read_attach,omitempty"`
+	}
+
+	// ReadAttach is the read_attach permission claim of a token.
+	ReadAttach struct {
+		// Databases are the names of the databases that can be attached.
+		Databases []string `json:"databases"`
+	}
+)
+
+// NewPermissions returns empty permissions.
+func NewPermissions() Permissions {
+	return Permissions{}
+}
+
+// AllowReadAttach returns a copy of the permissions also allowing the given
+// databases to be attached read-only.
+func (p Permissions) AllowReadAttach(databases ...string) Permissions {
+	readAttach := &ReadAttach{}
+	if p.ReadAttach != nil {
+		readAttach.Databases = append(readAttach.Databases, p.ReadAttach.Databases...)
+	}
+	readAttach.Databases = append(readAttach.Databases, databases...)
+	p.ReadAttach = readAttach
+	return p
+}
+
+// validate reports whether every database named by the permissions has a
+// valid name.
+func (p Permissions) validate() error {
+	if p.ReadAttach == nil {
+		return nil
+	}
+	for _, db := range p.ReadAttach.Databases {
+		err := ValidateDatabaseName(db)
+		if err != nil {
+			return fmt.Errorf("invalid read_attach permission: %w", err)
+		}
+	}
+	return nil
+}
+
+// RotatedToken is a token minted by RotateDatabaseToken.
+type RotatedToken struct {
+	// Token is the new token.
+	Token string
+	// InvalidatedAt is the time the previous tokens were invalidated.
+	InvalidatedAt time.Time
+	// IssuedAt is the time the new token was minted.
+	IssuedAt time.Time
+	// ExpiresAt is the time the new token expires, zero if it never expires.
+	ExpiresAt time.Time
+}
+
+// InvalidateDatabaseTokens invalidates every token of the database with the
+// given name.
+func (c *Client) InvalidateDatabaseTokens(ctx context.Context, dbName string) error {
+	err := c.invalidateTokens(
+		ctx,
+		fmt.Sprintf(
+			"%s/organizations/%s/databases/%s/auth/rotate",
+			c.baseURL, c.orgName, dbName,
+		),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to invalidate tokens of database %s: %w",
+			dbName, err,
+		)
+	}
+	return nil
+}
+
+// RotateDatabaseToken invalidates every token of the database with the given
+// name and mints a new one with the given expiration and authorization.
+func (c *Client) RotateDatabaseToken(
+	ctx context.Context,
+	dbName string,
+	opts ...newDbTokenOpt,
+) (*RotatedToken, error) {
+	config := TokenConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.err != nil {
+		return nil, config.err
+	}
+	expiry := NeverExpire
+	if config.expiration != "" {
+		var err error
+		expiry, err = ParseTokenExpiry(config.expiration)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := c.InvalidateDatabaseTokens(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	invalidatedAt := time.Now()
+	token, err := c.CreateDatabaseToken(ctx, dbName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	rotated := &RotatedToken{
+		Token:         token,
+		InvalidatedAt: invalidatedAt,
+		IssuedAt:      time.Now(),
+	}
+	if !expiry.Never() {
+		rotated.ExpiresAt = rotated.IssuedAt.Add(expiry.Duration())
+	}
+	return rotated, nil
+}
+
+// createToken mints a token at the given auth tokens endpoint.
+func (c *Client) createToken(
+	ctx context.Context,
+	uri string,
+	opts []newDbTokenOpt,
+) (string, error) {
+	config := TokenConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.err != nil {
+		return "", config.err
+	}
+	if config.expiration != "" {
+		_, err := ParseTokenExpiry(config.expiration)
+		if err != nil {
+			return "", err
+		}
+	}
+	setters := []builders.RequestOption{builders.WithQuerier(config)}
+	if config.permissions != nil {
+		err := config.permissions.validate()
+		if err != nil {
+			return "", err
+		}
+		setters = append(setters, builders.WithBody(struct {
+			Permissions *Permissions `json:"permissions"`
+		}{config.permissions}))
+	}
+	// Minting another token is harmless, so failed attempts can be retried.
+	req, err := builders.NewRequest(
+		RetrySafe(ctx),
+		c.header,
+		http.MethodPost,
+		uri,
+		setters...,
+	)
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		Token string `json:"jwt"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.Token, nil
+}
+
+// invalidateTokens invalidates every token at the given auth rotate
+// endpoint.
+func (c *Client) invalidateTokens(ctx context.Context, uri string) error {
+	// Invalidating tokens again is harmless, so failed attempts can be
+	// retried.
+	req, err := builders.NewRequest(
+		RetrySafe(ctx),
+		c.header,
+		http.MethodPost,
+		uri,
+	)
+	if err != nil {
+		return err
+	}
+	return c.sendRequest(req, nil)
+}
+
+// ErrInvalidExpiry is matched by errors.Is when a token expiration is not
+// in the compact syntax of the turso API.
+var ErrInvalidExpiry = errors.New("dbpu: invalid token expiration")
+
+// TokenExpiry is the lifetime of a token.
+//
+// The zero value never expires.
+type TokenExpiry time.Duration
+
+// NeverExpire is the TokenExpiry of tokens that never expire.
+const NeverExpire TokenExpiry = 0
+
+// expiryUnits are the units of the compact expiration syntax of the turso
+// API, largest first.
+var expiryUnits = []struct {
+	suffix string
+	unit   time.Duration
+}{
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+}
+
+// ParseTokenExpiry parses an expiration in the compact syntax of the turso
+// API (e.g., 2w1d30m) or the "never" sentinel.
+//
+// The returned error matches ErrInvalidExpiry.
+func ParseTokenExpiry(s string) (TokenExpiry, error) {
+	if s == "never" {
+		return NeverExpire, nil
+	}
+	if s == "" {
+		return 0, fmt.Errorf("%w: empty expiration", ErrInvalidExpiry)
+	}
+	var total time.Duration
+	rest := s
+	next := 0
+	for rest != "" {
+		i := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+		if i <= 0 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidExpiry, s)
+		}
+		n, err := strconv.ParseInt(rest[:i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q: %w", ErrInvalidExpiry, s, err)
+		}
+		// Units must appear at most once, largest first.
+		unit := -1
+		for j := next; j < len(expiryUnits); j++ {
+			if strings.HasPrefix(rest[i:], expiryUnits[j].suffix) {
+				unit = j
+				break
+			}
+		}
+		if unit < 0 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidExpiry, s)
+		}
+		next = unit + 1
+		total += time.Duration(n) * expiryUnits[unit].unit
+		rest = rest[i+len(expiryUnits[unit].suffix):]
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("%w: %q is not positive", ErrInvalidExpiry, s)
+	}
+	return TokenExpiry(total), nil
+}
+
+// String formats the expiry in the compact syntax of the turso API, or
+// returns "never" for NeverExpire.
+//
+// Expiries are truncated to the second.
+func (e TokenExpiry) String() string {
+	if e.Never() {
+		return "never"
+	}
+	d := time.Duration(e)
+	var b strings.Builder
+	for _, u := range expiryUnits {
+		if n := d / u.unit; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, u.suffix)
+			d -= n * u.unit
+		}
+	}
+	return b.String()
+}
+
+// Duration returns the lifetime of the token, zero if it never expires.
+func (e TokenExpiry) Duration() time.Duration {
+	return time.Duration(e)
+}
+
+// Never reports whether the token never expires.
+func (e TokenExpiry) Never() bool {
+	return e == NeverExpire
+}
+
+// Validate reports whether the expiry can be sent to the turso API: it must
+// not be negative and must be at least a second unless it never expires.
+func (e TokenExpiry) Validate() error {
+	switch {
+	case e < 0:
+		return fmt.Errorf("%w: %s is negative", ErrInvalidExpiry, time.Duration(e))
+	case e > 0 && time.Duration(e) < time.Second:
+		return fmt.Errorf("%w: %s is shorter than a second", ErrInvalidExpiry, time.Duration(e))
+	}
+	return nil
+}
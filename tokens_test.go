@@ -0,0 +1,149 @@
+package dbpu
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingServer answers every request with the given body and records the
+// method, path and query of the requests along with their bodies.
+func recordingServer(t *testing.T, body string) (*httptest.Server, *[]string, *[]string) {
+	t.Helper()
+	var requests, bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		bodies = append(bodies, string(raw))
+		_, _ = w.Write([]byte(body))
+	}))
+	return srv, &requests, &bodies
+}
+
+func TestTokenExpiry_RoundTrip(t *testing.T) {
+	tests := []struct {
+		expiry TokenExpiry
+		want   string
+	}{
+		{NeverExpire, "never"},
+		{TokenExpiry(30 * time.Minute), "30m"},
+		{TokenExpiry(15*24*time.Hour + 30*time.Minute), "2w1d30m"},
+		{TokenExpiry(time.Hour + 5*time.Second), "1h5s"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.expiry.String())
+		got, err := ParseTokenExpiry(tt.want)
+		assert.NoError(t, err, tt.want)
+		assert.Equal(t, tt.expiry, got, tt.want)
+	}
+}
+
+func TestParseTokenExpiry_Invalid(t *testing.T) {
+	for _, s := range []string{"", "2", "w", "2x", "1d2w", "1m1m", "0m", "-1d", "1d "} {
+		_, err := ParseTokenExpiry(s)
+		assert.ErrorIs(t, err, ErrInvalidExpiry, s)
+	}
+}
+
+func TestTokenExpiry_Validate(t *testing.T) {
+	assert.NoError(t, NeverExpire.Validate())
+	assert.NoError(t, TokenExpiry(time.Second).Validate())
+	assert.ErrorIs(t, TokenExpiry(-time.Hour).Validate(), ErrInvalidExpiry)
+	assert.ErrorIs(t, TokenExpiry(time.Millisecond).Validate(), ErrInvalidExpiry)
+}
+
+func signTestToken(t *testing.T, key ed25519.PrivateKey, claims string) string {
+	t.Helper()
+	enc := base64.RawURLEncoding
+	signed := enc.EncodeToString([]byte(`{"alg":"EdDSA","typ":"JWT"}`)) +
+		"." + enc.EncodeToString([]byte(claims))
+	return signed + "." + enc.EncodeToString(ed25519.Sign(key, []byte(signed)))
+}
+
+func TestParseDatabaseToken(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	token := signTestToken(t, private, `{
+		"a": "ro",
+		"iat": 1700000000,
+		"exp": 1700003600,
+		"id": "db-uuid",
+		"p": {"roa": {"ns": ["shared"]}}
+	}`)
+
+	claims, err := ParseDatabaseToken(token)
+	assert.NoError(t, err)
+	assert.True(t, claims.ReadOnly())
+	assert.Equal(t, "db-uuid", claims.DatabaseID)
+	assert.Equal(t, time.Unix(1700000000, 0), claims.IssuedAt)
+	assert.Equal(t, time.Unix(1700003600, 0), claims.ExpiresAt)
+	assert.Equal(t, []string{"shared"}, claims.Permissions.ReadAttach.Namespaces)
+	assert.False(t, claims.ExpiredAt(time.Unix(1700003599, 0)))
+	assert.True(t, claims.ExpiredAt(time.Unix(1700003600, 0)))
+
+	_, err = ParseDatabaseToken(token, WithPublicKey(public))
+	assert.NoError(t, err)
+
+	other, _, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	_, err = ParseDatabaseToken(token, WithPublicKey(other))
+	assert.ErrorIs(t, err, ErrTokenSignature)
+
+	never, err := ParseDatabaseToken(signTestToken(t, private, `{"a":"rw","gid":"g"}`))
+	assert.NoError(t, err)
+	assert.False(t, never.Expires())
+	assert.Equal(t, "g", never.GroupID)
+
+	_, err = ParseDatabaseToken("not-a-token")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestRotateDatabaseToken(t *testing.T) {
+	srv, requests, _ := recordingServer(t, `{"jwt":"new-token"}`)
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+
+	rotated, err := c.RotateDatabaseToken(context.Background(), "db",
+		WithTokenExpiry(TokenExpiry(time.Hour)),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "new-token", rotated.Token)
+	assert.False(t, rotated.IssuedAt.Before(rotated.InvalidatedAt))
+	assert.Equal(t, time.Hour, rotated.ExpiresAt.Sub(rotated.IssuedAt))
+	assert.Equal(t, []string{
+		"POST /organizations/org/databases/db/auth/rotate",
+		"POST /organizations/org/databases/db/auth/tokens?expiration=1h",
+	}, *requests)
+}
+
+func TestCreateDatabaseToken_Permissions(t *testing.T) {
+	srv, requests, bodies := recordingServer(t, `{"jwt":"token"}`)
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	_, err := c.CreateDatabaseToken(ctx, "db",
+		WithPermissions(NewPermissions().AllowReadAttach("shared", "catalog")),
+	)
+	require.NoError(t, err)
+	require.Len(t, *bodies, 1)
+	assert.JSONEq(t,
+		`{"permissions":{"read_attach":{"databases":["shared","catalog"]}}}`,
+		(*bodies)[0],
+	)
+
+	_, err = c.CreateDatabaseToken(ctx, "db",
+		WithPermissions(NewPermissions().AllowReadAttach("Not_Valid")),
+	)
+	assert.ErrorIs(t, err, ErrInvalidName)
+	assert.Len(t, *requests, 1)
+}
@@ -0,0 +1,168 @@
+package dbpu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/conneroisu/dbpu/internal/builders"
+)
+
+// UploadDump streams the SQL dump read from r to the turso API and returns
+// the URL a database can be seeded from.
+func (c *Client) UploadDump(ctx context.Context, r io.Reader) (string, error) {
+	body, writer := io.Pipe()
+	form := builders.NewFormBuilder(writer)
+	go func() {
+		err := form.CreateFormFileReader("file", r, "dump.sql")
+		if err == nil {
+			err = form.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodPost,
+		fmt.Sprintf(
+			"%s/organizations/%s/databases/dumps",
+			c.baseURL, c.orgName,
+		),
+		builders.WithBody(body),
+		builders.WithContentType(form.FormDataContentType()),
+	)
+	if err != nil {
+		body.CloseWithError(err)
+		return "", err
+	}
+	var resp struct {
+		DumpURL string `json:"dump_url"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		// The request may fail before the transport consumes the body (e.g.,
+		// while waiting on the rate limiter), so unblock the writer.
+		body.CloseWithError(err)
+		return "", fmt.Errorf("failed to upload dump: %w", err)
+	}
+	return resp.DumpURL, nil
+}
+
+// CreateFromDump uploads the SQL dump read from r and creates a database
+// seeded from it.
+//
+// The seed of the config is replaced by the uploaded dump.
+func (c *Client) CreateFromDump(
+	ctx context.Context,
+	config Config,
+	r io.Reader,
+) (*Database, error) {
+	// Validate before uploading so that an invalid config fails fast.
+	config.Seed = nil
+	err := c.validate(config)
+	if err != nil {
+		return nil, err
+	}
+	dumpURL, err := c.UploadDump(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	config.Seed = &Seed{Type: SeedDump, URL: dumpURL}
+	return c.Create(ctx, config)
+}
+
+// CreateFromDumpFile uploads the SQL dump file at the given path and creates
+// a database seeded from it.
+func (c *Client) CreateFromDumpFile(
+	ctx context.Context,
+	config Config,
+	path string,
+) (*Database, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return c.CreateFromDump(ctx, config, file)
+}
+
+// UploadDatabase creates a database awaiting an upload and streams the SQLite
+// database file read from r to it.
+//
+// The seed of the config is replaced by a database upload seed. If the upload
+// fails after the database was created, the empty database is deleted on a
+// best-effort basis and the returned error joins the cleanup error, if any.
+func (c *Client) UploadDatabase(
+	ctx context.Context,
+	config Config,
+	r io.Reader,
+) (*Database, error) {
+	config.Seed = &Seed{Type: SeedDatabaseUpload}
+	db, err := c.Create(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	err = c.upload(ctx, db, r)
+	if err != nil {
+		// Delete even if ctx was cancelled so that no orphan is left behind.
+		cleanupErr := c.DeleteDatabase(context.WithoutCancel(ctx), db.Name)
+		if cleanupErr != nil {
+			cleanupErr = fmt.Errorf(
+				"failed to delete database %s after failed upload: %w",
+				db.Name, cleanupErr,
+			)
+		}
+		return nil, errors.Join(err, cleanupErr)
+	}
+	return db, nil
+}
+
+// upload streams the SQLite database file read from r to the database
+// awaiting an upload.
+func (c *Client) upload(ctx context.Context, db *Database, r io.Reader) error {
+	token, err := c.CreateDatabaseToken(ctx, db.Name)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("https://%s/v1/upload", db.Hostname),
+		r,
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	res, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload database %s: %w", db.Name, err)
+	}
+	defer res.Body.Close()
+	if isFailureStatusCode(res) {
+		return fmt.Errorf(
+			"failed to upload database %s: %w",
+			db.Name, c.handleErrorResp(res),
+		)
+	}
+	return nil
+}
+
+// UploadDatabaseFile creates a database awaiting an upload and streams the
+// SQLite database file at the given path to it.
+func (c *Client) UploadDatabaseFile(
+	ctx context.Context,
+	config Config,
+	path string,
+) (*Database, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return c.UploadDatabase(ctx, config, file)
+}
@@ -0,0 +1,97 @@
+package dbpu
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateFromDump(t *testing.T) {
+	var created Config
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/organizations/org/databases/dumps":
+			file, header, err := r.FormFile("file")
+			require.NoError(t, err)
+			defer file.Close()
+			assert.Equal(t, "dump.sql", header.Filename)
+			dump, err := io.ReadAll(file)
+			require.NoError(t, err)
+			assert.Equal(t, "CREATE TABLE t (id INTEGER);", string(dump))
+			_, _ = w.Write([]byte(`{"dump_url":"https://dumps.turso.io/abc"}`))
+		case "/organizations/org/databases":
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			_, _ = w.Write([]byte(`{"database":{"Name":"db"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+
+	db, err := c.CreateFromDump(context.Background(),
+		Config{Name: "db", Location: "ams", Group: "default"},
+		strings.NewReader("CREATE TABLE t (id INTEGER);"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "db", db.Name)
+	assert.Equal(t, &Seed{Type: SeedDump, URL: "https://dumps.turso.io/abc"}, created.Seed)
+}
+
+func TestUploadDatabase_CleanupOnFailure(t *testing.T) {
+	for _, deleteStatus := range []int{http.StatusOK, http.StatusInternalServerError} {
+		var deleted bool
+		var srv *httptest.Server
+		srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/organizations/org/databases":
+				var config Config
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&config))
+				assert.Equal(t, SeedDatabaseUpload, config.Seed.Type)
+				host := strings.TrimPrefix(srv.URL, "https://")
+				_, _ = w.Write([]byte(`{"database":{"Name":"db","Hostname":"` + host + `"}}`))
+			case r.URL.Path == "/organizations/org/databases/db/auth/tokens":
+				_, _ = w.Write([]byte(`{"jwt":"db-token"}`))
+			case r.URL.Path == "/v1/upload":
+				assert.Equal(t, "Bearer db-token", r.Header.Get("Authorization"))
+				assert.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error":"upload failed"}`))
+			case r.Method == http.MethodDelete && r.URL.Path == "/organizations/org/databases/db":
+				deleted = true
+				w.WriteHeader(deleteStatus)
+				if deleteStatus != http.StatusOK {
+					_, _ = w.Write([]byte(`{"error":"delete failed"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"database":"db"}`))
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		c := NewClient("token", "org", WithBaseURL(srv.URL), WithClient(srv.Client()))
+
+		db, err := c.UploadDatabase(context.Background(),
+			Config{Name: "db", Location: "ams", Group: "default"},
+			strings.NewReader("SQLite format 3\x00"),
+		)
+		srv.Close()
+		assert.Nil(t, db)
+		assert.True(t, deleted, deleteStatus)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to upload database db")
+		if deleteStatus == http.StatusOK {
+			assert.NotContains(t, err.Error(), "failed to delete")
+		} else {
+			assert.Contains(t, err.Error(), "failed to delete database db after failed upload")
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package dbpu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/conneroisu/dbpu/internal/builders"
+)
+
+type (
+	usageOpt func(*UsageConfig)
+
+	// UsageConfig is a configuration for fetching the usage of a database.
+	UsageConfig struct {
+		// From is the start of the usage window.
+		From time.Time `url:"from,omitempty"`
+		// To is the end of the usage window.
+		To time.Time `url:"to,omitempty"`
+		// filters select the databases aggregated by GetOrgUsage.
+		filters []listDbOpt
+	}
+
+	// Usage is the usage of a database or an instance.
+	Usage struct {
+		RowsRead     uint64 `json:"rows_read"`
+		RowsWritten  uint64 `json:"rows_written"`
+		StorageBytes uint64 `json:"storage_bytes"`
+		BytesSynced  uint64 `json:"bytes_synced"`
+	}
+
+	// InstanceUsage is the usage of an instance of a database.
+	InstanceUsage struct {
+		ID    string `json:"uuid"`
+		Usage Usage  `json:"usage"`
+	}
+
+	// DatabaseUsage is the usage of a database over a window.
+	DatabaseUsage struct {
+		ID        string          `json:"uuid"`
+		Instances []InstanceUsage `json:"instances"`
+		Total     Usage           `json:"total"`
+	}
+
+	// DatabaseStats are the statistics of a database.
+	DatabaseStats struct {
+		TopQueries []QueryStats `json:"top_queries"`
+	}
+
+	// QueryStats are the statistics of a query run against a database.
+	QueryStats struct {
+		Query       string `json:"query"`
+		RowsRead    uint64 `json:"rows_read"`
+		RowsWritten uint64 `json:"rows_written"`
+	}
+
+	// OrgUsage is the usage of the databases of an organization over a
+	// window.
+	OrgUsage struct {
+		// Databases is the usage of each database keyed by name.
+		Databases map[string]DatabaseUsage
+		// Total is the usage summed over every database.
+		Total Usage
+		// Missing lists the databases deleted between their listing and
+		// the fetch of their usage, which are left out of the total.
+		Missing []string
+	}
+)
+
+// WithUsageWindow restricts the usage to the window between from and to.
+//
+// A zero time leaves the corresponding bound to the turso API, which defaults
+// to the current billing cycle.
+func WithUsageWindow(from, to time.Time) func(*UsageConfig) {
+	return func(c *UsageConfig) {
+		c.From = from
+		c.To = to
+	}
+}
+
+// WithUsageFilter restricts GetOrgUsage to the databases matching the given
+// list options, such as WithGroupFilter. It is ignored by GetDatabaseUsage.
+func WithUsageFilter(opts ...listDbOpt) func(*UsageConfig) {
+	return func(c *UsageConfig) { c.filters = append(c.filters, opts...) }
+}
+
+// GetDatabaseUsage returns the usage of the database with the given name.
+func (c *Client) GetDatabaseUsage(
+	ctx context.Context,
+	dbName string,
+	opts ...usageOpt,
+) (*DatabaseUsage, error) {
+	config := UsageConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	uri, err := url.Parse(fmt.Sprintf(
+		"%s/organizations/%s/databases/%s/usage",
+		c.baseURL, c.orgName, dbName,
+	))
+	if err != nil {
+		return nil, err
+	}
+	vals, err := builders.Values(config)
+	if err != nil {
+		return nil, err
+	}
+	uri.RawQuery = vals.Encode()
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		uri.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Database DatabaseUsage `json:"database"`
+	}
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get usage of database %s: %w",
+			dbName, err,
+		)
+	}
+	return &resp.Database, nil
+}
+
+// GetDatabaseStats returns the statistics of the database with the given
+// name, such as its top queries.
+func (c *Client) GetDatabaseStats(
+	ctx context.Context,
+	dbName string,
+) (*DatabaseStats, error) {
+	req, err := builders.NewRequest(
+		ctx,
+		c.header,
+		http.MethodGet,
+		fmt.Sprintf(
+			"%s/organizations/%s/databases/%s/stats",
+			c.baseURL, c.orgName, dbName,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var resp DatabaseStats
+	err = c.sendRequest(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get stats of database %s: %w",
+			dbName, err,
+		)
+	}
+	return &resp, nil
+}
+
+// GetOrgUsage returns the usage of every database of the organization,
+// fetched one database at a time.
+//
+// WithUsageFilter can be provided to only aggregate the databases of a group
+// or schema. Databases deleted while the usage is aggregated are reported in
+// Missing rather than failing the aggregation.
+func (c *Client) GetOrgUsage(
+	ctx context.Context,
+	opts ...usageOpt,
+) (*OrgUsage, error) {
+	config := UsageConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	dbs, err := c.ListDatabases(ctx, config.filters...)
+	if err != nil {
+		return nil, err
+	}
+	usage := &OrgUsage{Databases: make(map[string]DatabaseUsage, len(dbs))}
+	for _, db := range dbs {
+		dbUsage, err := c.GetDatabaseUsage(
+			ctx,
+			db.Name,
+			WithUsageWindow(config.From, config.To),
+		)
+		if errors.Is(err, ErrNotFound) {
+			usage.Missing = append(usage.Missing, db.Name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		usage.Databases[db.Name] = *dbUsage
+		usage.Total.add(dbUsage.Total)
+	}
+	return usage, nil
+}
+
+// add adds the other usage to the usage.
+func (u *Usage) add(other Usage) {
+	u.RowsRead += other.RowsRead
+	u.RowsWritten += other.RowsWritten
+	u.StorageBytes += other.StorageBytes
+	u.BytesSynced += other.BytesSynced
+}
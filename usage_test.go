@@ -0,0 +1,43 @@
+package dbpu
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrgUsage(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/organizations/org/databases":
+			assert.Equal(t, "tenants", r.URL.Query().Get("group"))
+			_, _ = w.Write([]byte(`{"databases":[{"Name":"a"},{"Name":"gone"},{"Name":"b"}]}`))
+		case "/organizations/org/databases/gone/usage":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"database not found"}`))
+		default:
+			assert.NotEmpty(t, r.URL.Query().Get("from"))
+			assert.NotEmpty(t, r.URL.Query().Get("to"))
+			assert.Empty(t, r.URL.Query().Get("group"))
+			_, _ = w.Write([]byte(`{"database":{"total":{"rows_read":10,"rows_written":2,"storage_bytes":100}}}`))
+		}
+	}))
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+
+	usage, err := c.GetOrgUsage(context.Background(),
+		WithUsageWindow(from, to),
+		WithUsageFilter(WithGroupFilter("tenants")),
+	)
+	require.NoError(t, err)
+	assert.Len(t, usage.Databases, 2)
+	assert.Equal(t, []string{"gone"}, usage.Missing)
+	assert.Equal(t, Usage{RowsRead: 20, RowsWritten: 4, StorageBytes: 200}, usage.Total)
+}
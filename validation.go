@@ -0,0 +1,92 @@
+package dbpu
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type (
+	// ValidationError is returned when a configuration fails validation
+	// before any request is made.
+	ValidationError struct {
+		// Fields lists the failing fields.
+		Fields []FieldError `json:"fields"`
+	}
+
+	// FieldError describes a field failing validation.
+	FieldError struct {
+		// Field is the JSON path of the field (e.g., seed.type).
+		Field string `json:"field"`
+		// Rule is the validation rule the field failed (e.g., required).
+		Rule string `json:"rule"`
+		// Param is the parameter of the rule, if any (e.g., the allowed
+		// values of oneof).
+		Param string `json:"param,omitempty"`
+		// Value is the rejected value.
+		Value any `json:"value"`
+	}
+)
+
+// Error implements the error interface on ValidationError.
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		fields = append(fields, f.String())
+	}
+	return "validation failed: " + strings.Join(fields, "; ")
+}
+
+// String returns a human readable description of the field error.
+func (f FieldError) String() string {
+	if f.Param != "" {
+		return fmt.Sprintf("%s failed %s=%s (got %v)", f.Field, f.Rule, f.Param, f.Value)
+	}
+	return fmt.Sprintf("%s failed %s (got %v)", f.Field, f.Rule, f.Value)
+}
+
+// newValidator returns a validator reporting fields by their JSON name and
+// knowing the turso specific rules:
+//
+//   - turso_name: a valid database or group name (see ValidateDatabaseName).
+//   - turso_location: a location accepted by the given catalog.
+func newValidator(locations *locationCatalog) *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(sf reflect.StructField) string {
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return sf.Name
+		}
+		return name
+	})
+	_ = v.RegisterValidation("turso_name", func(fl validator.FieldLevel) bool {
+		return ValidateDatabaseName(fl.Field().String()) == nil
+	})
+	_ = v.RegisterValidation("turso_location", locations.validateLocation)
+	return v
+}
+
+// newValidationError converts the errors of a validator into a
+// ValidationError.
+func newValidationError(errs validator.ValidationErrors) *ValidationError {
+	fields := make([]FieldError, 0, len(errs))
+	for _, fe := range errs {
+		field := fe.Namespace()
+		// Drop the name of the validated struct.
+		if _, path, ok := strings.Cut(field, "."); ok {
+			field = path
+		}
+		fields = append(fields, FieldError{
+			Field: field,
+			Rule:  fe.Tag(),
+			Param: fe.Param(),
+			Value: fe.Value(),
+		})
+	}
+	return &ValidationError{Fields: fields}
+}
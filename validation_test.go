@@ -0,0 +1,72 @@
+package dbpu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ValidationError(t *testing.T) {
+	c := NewClient("token", "org")
+	tests := []struct {
+		name   string
+		config Config
+		want   []FieldError
+	}{
+		{
+			name: "seed",
+			config: Config{
+				Name: "db", Location: "ams", Group: "default",
+				Seed: &Seed{Type: SeedDump},
+			},
+			want: []FieldError{
+				{Field: "seed.url", Rule: "required_if", Param: "Type dump", Value: ""},
+			},
+		},
+		{
+			name:   "name",
+			config: Config{Name: "Bad_Name", Location: "ams", Group: "default"},
+			want: []FieldError{
+				{Field: "name", Rule: "turso_name", Value: "Bad_Name"},
+			},
+		},
+		{
+			name:   "location",
+			config: Config{Name: "db", Location: "Not A Region", Group: "default"},
+			want: []FieldError{
+				{Field: "location", Rule: "turso_location", Value: Location("Not A Region")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		err := c.validate(tt.config)
+		var verr *ValidationError
+		require.True(t, errors.As(err, &verr), tt.name)
+		assert.Equal(t, tt.want, verr.Fields, tt.name)
+	}
+}
+
+func TestCreate_ValidationError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+	c := NewClient("token", "org", WithBaseURL(srv.URL))
+
+	_, err := c.Create(context.Background(), Config{Location: "ams", Group: "default"})
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	require.Len(t, verr.Fields, 1)
+	assert.Equal(t, "name", verr.Fields[0].Field)
+	assert.Equal(t, "required", verr.Fields[0].Rule)
+
+	encoded, err := json.Marshal(verr)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"fields":[{"field":"name","rule":"required","value":""}]}`, string(encoded))
+}